@@ -4,24 +4,135 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 )
 
 type StringArg struct {
-	Name        string
-	Description string
-	Value       string
-	Required    bool
-	Options     []string
-	Validator   func(string) error
+	Name         string
+	Description  string
+	Value        string
+	Required     bool
+	Options      []string
+	Validator    func(string) error
+	CompleteFunc CompletionFunc
+	argState
 }
 
 type ArrayArg struct {
-	Name        string
-	Description string
-	Value       []string
-	Min         uint
-	Options     []string
-	Validator   func([]string) error
+	Name         string
+	Description  string
+	Value        []string
+	Min          uint
+	Options      []string
+	Validator    func([]string) error
+	CompleteFunc CompletionFunc
+	argState
+}
+
+type IntArg struct {
+	Name         string
+	Description  string
+	Value        int
+	Required     bool
+	Validator    func(int) error
+	CompleteFunc CompletionFunc
+	argState
+}
+
+type UintArg struct {
+	Name         string
+	Description  string
+	Value        uint
+	Required     bool
+	Validator    func(uint) error
+	CompleteFunc CompletionFunc
+	argState
+}
+
+type FloatArg struct {
+	Name         string
+	Description  string
+	Value        float64
+	Required     bool
+	Validator    func(float64) error
+	CompleteFunc CompletionFunc
+	argState
+}
+
+type DurationArg struct {
+	Name         string
+	Description  string
+	Value        time.Duration
+	Required     bool
+	Validator    func(time.Duration) error
+	CompleteFunc CompletionFunc
+	argState
+}
+
+type TimeArg struct {
+	Name         string
+	Description  string
+	Value        time.Time
+	Layout       string
+	Required     bool
+	Validator    func(time.Time) error
+	CompleteFunc CompletionFunc
+	argState
+}
+
+// argState is the parse state of an argument.
+type argState struct {
+	err error
+	// restore restores the value the argument had when it was first bound
+	restore func()
+}
+
+func (s *argState) state() *argState {
+	return s
+}
+
+type statefulArg interface {
+	state() *argState
+}
+
+// resetArg restores the value the argument had when it was first bound, so that a
+// command can be executed more than once.
+func resetArg(a Arg) {
+	s, ok := a.(statefulArg)
+	if !ok {
+		return
+	}
+
+	state := s.state()
+	state.err = nil
+
+	if state.restore == nil {
+		state.restore = argRestorer(a)
+	} else {
+		state.restore()
+	}
+}
+
+// argRestorer returns a function that restores the current value of the argument.
+func argRestorer(a Arg) func() {
+	switch arg := a.(type) {
+	case *StringArg:
+		return restorer(&arg.Value)
+	case *ArrayArg:
+		return sliceRestorer(&arg.Value)
+	case *IntArg:
+		return restorer(&arg.Value)
+	case *UintArg:
+		return restorer(&arg.Value)
+	case *FloatArg:
+		return restorer(&arg.Value)
+	case *DurationArg:
+		return restorer(&arg.Value)
+	case *TimeArg:
+		return restorer(&arg.Value)
+	default:
+		return func() {}
+	}
 }
 
 type Arg interface {
@@ -52,6 +163,10 @@ func (a *StringArg) Opts() []string {
 	return a.Options
 }
 
+func (a *StringArg) GetCompleteFunc() CompletionFunc {
+	return a.CompleteFunc
+}
+
 func (a *ArrayArg) GetName() string {
 	return a.Name
 }
@@ -72,12 +187,169 @@ func (a *ArrayArg) Opts() []string {
 	return a.Options
 }
 
+func (a *ArrayArg) GetCompleteFunc() CompletionFunc {
+	return a.CompleteFunc
+}
+
+func (a *IntArg) GetName() string {
+	return a.Name
+}
+
+func (a *IntArg) GetDescription() string {
+	return a.Description
+}
+
+func (a *IntArg) IsRequired() bool {
+	return a.Required
+}
+
+func (a *IntArg) HasValue() bool {
+	return a.Value != 0
+}
+
+func (a *IntArg) Opts() []string {
+	return nil
+}
+
+func (a *IntArg) GetCompleteFunc() CompletionFunc {
+	return a.CompleteFunc
+}
+
+func (a *UintArg) GetName() string {
+	return a.Name
+}
+
+func (a *UintArg) GetDescription() string {
+	return a.Description
+}
+
+func (a *UintArg) IsRequired() bool {
+	return a.Required
+}
+
+func (a *UintArg) HasValue() bool {
+	return a.Value != 0
+}
+
+func (a *UintArg) Opts() []string {
+	return nil
+}
+
+func (a *UintArg) GetCompleteFunc() CompletionFunc {
+	return a.CompleteFunc
+}
+
+func (a *FloatArg) GetName() string {
+	return a.Name
+}
+
+func (a *FloatArg) GetDescription() string {
+	return a.Description
+}
+
+func (a *FloatArg) IsRequired() bool {
+	return a.Required
+}
+
+func (a *FloatArg) HasValue() bool {
+	return a.Value != 0
+}
+
+func (a *FloatArg) Opts() []string {
+	return nil
+}
+
+func (a *FloatArg) GetCompleteFunc() CompletionFunc {
+	return a.CompleteFunc
+}
+
+func (a *DurationArg) GetName() string {
+	return a.Name
+}
+
+func (a *DurationArg) GetDescription() string {
+	return a.Description
+}
+
+func (a *DurationArg) IsRequired() bool {
+	return a.Required
+}
+
+func (a *DurationArg) HasValue() bool {
+	return a.Value != 0
+}
+
+func (a *DurationArg) Opts() []string {
+	return nil
+}
+
+func (a *DurationArg) GetCompleteFunc() CompletionFunc {
+	return a.CompleteFunc
+}
+
+func (a *TimeArg) GetName() string {
+	return a.Name
+}
+
+func (a *TimeArg) GetDescription() string {
+	return a.Description
+}
+
+func (a *TimeArg) IsRequired() bool {
+	return a.Required
+}
+
+func (a *TimeArg) HasValue() bool {
+	return !a.Value.IsZero()
+}
+
+func (a *TimeArg) Opts() []string {
+	return nil
+}
+
+func (a *TimeArg) GetCompleteFunc() CompletionFunc {
+	return a.CompleteFunc
+}
+
 func GetArgStringValue(arg Arg) string {
-	if a, ok := arg.(*StringArg); ok {
+	switch a := arg.(type) {
+	case *StringArg:
 		return a.Value
+	case *IntArg:
+		return (*intValue)(&a.Value).String()
+	case *UintArg:
+		return (*uintValue)(&a.Value).String()
+	case *FloatArg:
+		return (*floatValue)(&a.Value).String()
+	case *DurationArg:
+		return (*durationValue)(&a.Value).String()
+	case *TimeArg:
+		return (&timeValue{value: &a.Value, layout: a.Layout}).String()
+	default:
+		return ""
 	}
+}
 
-	return ""
+// GetArgValue returns the value of the argument, typed according to the kind of argument.
+func GetArgValue(arg Arg) InputType {
+	switch a := arg.(type) {
+	case *StringArg:
+		return a.Value
+	case *ArrayArg:
+		return GetArgArrayValue(a)
+	case *IntArg:
+		return a.Value
+	case *UintArg:
+		return a.Value
+	case *FloatArg:
+		return a.Value
+	case *DurationArg:
+		return a.Value
+	case *TimeArg:
+		return a.Value
+	default:
+		return nil
+	}
 }
 
 func GetArgArrayValue(arg Arg) []string {
@@ -132,6 +404,36 @@ func ValidateArg(arg Arg) error {
 		}
 
 		return nil
+	case *IntArg:
+		if a.err == nil && a.Validator != nil {
+			return a.Validator(a.Value)
+		}
+
+		return a.err
+	case *UintArg:
+		if a.err == nil && a.Validator != nil {
+			return a.Validator(a.Value)
+		}
+
+		return a.err
+	case *FloatArg:
+		if a.err == nil && a.Validator != nil {
+			return a.Validator(a.Value)
+		}
+
+		return a.err
+	case *DurationArg:
+		if a.err == nil && a.Validator != nil {
+			return a.Validator(a.Value)
+		}
+
+		return a.err
+	case *TimeArg:
+		if a.err == nil && a.Validator != nil {
+			return a.Validator(a.Value)
+		}
+
+		return a.err
 	default:
 		return errors.New("invalid argument type")
 	}
@@ -6,7 +6,6 @@ import (
 
 	"github.com/michielnijenhuis/cli/helper/array"
 	"github.com/michielnijenhuis/cli/helper/keys"
-	"github.com/michielnijenhuis/cli/terminal"
 )
 
 type ArrayPrompt struct {
@@ -101,8 +100,8 @@ func NewArrayPrompt(i *Input, o *Output, label string, defaultValue []string) *A
 }
 
 func (p *ArrayPrompt) String() string {
-	renderer := NewRenderer()
-	terminalWidth := terminal.Columns()
+	renderer := newRenderer(p.output.Width())
+	terminalWidth := p.output.Width()
 	maxWidth := terminalWidth - 6
 	state := p.State
 
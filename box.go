@@ -10,7 +10,13 @@ import (
 
 const defaultBoxColor = "gray"
 
+// Box renders a box that fits the terminal of stdin. Use Output.Box to fit the
+// terminal of an output instead.
 func Box(title string, body string, footer string, color string, info string) string {
+	return box(terminal.Columns(), title, body, footer, color, info)
+}
+
+func box(terminalWidth int, title string, body string, footer string, color string, info string) string {
 	if color == "" {
 		color = defaultBoxColor
 	}
@@ -18,7 +24,6 @@ func Box(title string, body string, footer string, color string, info string) st
 	var output strings.Builder
 
 	minWidth := 60
-	terminalWidth := terminal.Columns()
 	minWidth = min(minWidth, terminalWidth-6)
 
 	bodyLines := strings.Split(body, Eol)
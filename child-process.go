@@ -1,10 +1,13 @@
 package cli
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"os"
 	os_exec "os/exec"
 	"strings"
+	"time"
 
 	"github.com/michielnijenhuis/cli/terminal"
 )
@@ -14,12 +17,16 @@ type ChildProcess struct {
 	Args   []string
 	Shell  string
 	Pipe   bool
-	Stdin  *os.File
-	Stdout *os.File
-	Stderr *os.File
+	Stdin  io.Reader
+	Stdout io.Writer
+	Stderr io.Writer
 	err    error
 	Env    []string
-	c      *os_exec.Cmd
+	// Context interrupts the process when cancelled. WaitDelay bounds the time
+	// to wait for the process to exit after it has been interrupted.
+	Context   context.Context
+	WaitDelay time.Duration
+	c         *os_exec.Cmd
 }
 
 func (cp *ChildProcess) Run() (string, error) {
@@ -81,7 +88,16 @@ func (cp *ChildProcess) createCommand() *os_exec.Cmd {
 		args = []string{}
 	}
 
-	c := os_exec.Command(name, args...)
+	var c *os_exec.Cmd
+	if cp.Context != nil {
+		c = os_exec.CommandContext(cp.Context, name, args...)
+		c.Cancel = func() error {
+			return c.Process.Signal(os.Interrupt)
+		}
+		c.WaitDelay = cp.WaitDelay
+	} else {
+		c = os_exec.Command(name, args...)
+	}
 
 	if !cp.Pipe {
 		cp.inherit(c)
@@ -0,0 +1,202 @@
+// Package clitest executes commands in-process and captures their output, for use in
+// tests.
+package clitest
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/michielnijenhuis/cli"
+)
+
+// Options configure a single execution of a tester.
+type Options struct {
+	// Env is set for the duration of the execution, and restored afterwards.
+	Env map[string]string
+	// Interactive makes prompts read from Inputs instead of using their defaults.
+	Interactive bool
+	// Decorated keeps the ANSI codes of the styles in the captured output.
+	Decorated bool
+	// Inputs are written to stdin, each followed by a newline.
+	Inputs []string
+	// Terminal replaces stdin and stdout, and makes the execution interactive. Inputs
+	// are ignored; keys are queued on the terminal instead.
+	Terminal *Terminal
+	// Width and Height are the size of the terminal that the output is rendered for,
+	// 80x24 by default. They are ignored when Terminal is set, which has its own size.
+	Width  int
+	Height int
+}
+
+// The size of the terminal that testers render for by default.
+const (
+	DefaultWidth  = 80
+	DefaultHeight = 24
+)
+
+type tester struct {
+	stdout *bytes.Buffer
+	stderr *bytes.Buffer
+	code   int
+	err    error
+}
+
+// CommandTester executes a single command of an application. The arguments are given
+// relative to the command, e.g. without "deploy" for the "app deploy" command. A tester
+// can execute more than once, flags and arguments are reset to their defaults before
+// every execution.
+type CommandTester struct {
+	tester
+	Command *cli.Command
+}
+
+func NewCommandTester(command *cli.Command) *CommandTester {
+	return &CommandTester{Command: command}
+}
+
+// Execute executes the command with the given arguments. A single argument is split
+// like a command line.
+func (t *CommandTester) Execute(args []string, options *Options) (int, error) {
+	path := strings.Fields(t.Command.FullName())[1:]
+	if len(args) == 1 {
+		args = cli.StringToInputArgs(args[0])
+	}
+
+	return t.execute(t.Command.Root(), append(path, args...), options)
+}
+
+// ApplicationTester executes an application with the arguments of a full command line,
+// excluding the name of the application itself.
+type ApplicationTester struct {
+	tester
+	Application *cli.Command
+}
+
+func NewApplicationTester(application *cli.Command) *ApplicationTester {
+	return &ApplicationTester{Application: application}
+}
+
+// Execute executes the application with the given arguments. A single argument is
+// split like a command line.
+func (t *ApplicationTester) Execute(args []string, options *Options) (int, error) {
+	return t.execute(t.Application, args, options)
+}
+
+func (t *tester) execute(root *cli.Command, args []string, options *Options) (int, error) {
+	if options == nil {
+		options = &Options{}
+	}
+
+	restoreEnv := setEnv(options.Env)
+	defer restoreEnv()
+
+	autoExit := root.AutoExit
+	root.AutoExit = false
+	defer func() {
+		root.AutoExit = autoExit
+	}()
+
+	var stdin strings.Builder
+	for _, input := range options.Inputs {
+		stdin.WriteString(input + "\n")
+	}
+
+	t.stdout = new(bytes.Buffer)
+	t.stderr = new(bytes.Buffer)
+
+	// a nil slice makes the input fall back to the arguments of the process
+	i := cli.NewInput(append([]string{}, args...)...)
+	var o *cli.Output
+
+	if options.Terminal != nil {
+		written := len(options.Terminal.Output())
+		defer func() {
+			t.stdout.WriteString(options.Terminal.Output()[written:])
+		}()
+
+		i.SetStream(options.Terminal)
+		o = cli.NewStreamOutput(i, options.Terminal, t.stderr)
+	} else {
+		i.SetStream(strings.NewReader(stdin.String()))
+		i.SetInteractive(options.Interactive)
+		o = cli.NewStreamOutput(i, t.stdout, t.stderr)
+
+		width, height := options.Width, options.Height
+		if width <= 0 {
+			width = DefaultWidth
+		}
+		if height <= 0 {
+			height = DefaultHeight
+		}
+
+		o.SetSize(width, height)
+		o.Stderr.SetSize(width, height)
+	}
+
+	o.SetDecorated(options.Decorated)
+
+	t.code, t.err = root.ExecuteIO(context.Background(), i, o)
+
+	return t.code, t.err
+}
+
+// Display returns the output written to stdout by the last execution.
+func (t *tester) Display() string {
+	if t.stdout == nil {
+		return ""
+	}
+
+	return t.stdout.String()
+}
+
+// ErrorOutput returns the output written to stderr by the last execution.
+func (t *tester) ErrorOutput() string {
+	if t.stderr == nil {
+		return ""
+	}
+
+	return t.stderr.String()
+}
+
+// StatusCode returns the exit code of the last execution.
+func (t *tester) StatusCode() int {
+	return t.code
+}
+
+// Err returns the error of the last execution.
+func (t *tester) Err() error {
+	return t.err
+}
+
+// AssertGolden compares the display of the last execution with a golden file, see
+// AssertGolden.
+func (t *tester) AssertGolden(tb testing.TB, name string) {
+	tb.Helper()
+	AssertGolden(tb, name, t.Display())
+}
+
+func setEnv(env map[string]string) func() {
+	previous := make(map[string]*string, len(env))
+	for name, value := range env {
+		if old, ok := os.LookupEnv(name); ok {
+			previous[name] = &old
+		} else {
+			previous[name] = nil
+		}
+
+		os.Setenv(name, value)
+	}
+
+	return func() {
+		for name, value := range previous {
+			if value == nil {
+				os.Unsetenv(name)
+			} else {
+				os.Setenv(name, *value)
+			}
+		}
+	}
+}
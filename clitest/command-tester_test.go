@@ -0,0 +1,235 @@
+package clitest
+
+import (
+	"io"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/michielnijenhuis/cli"
+)
+
+func newApplication() *cli.Command {
+	app := &cli.Command{
+		Name:     "app",
+		AutoExit: true,
+	}
+
+	app.AddCommand(&cli.Command{
+		Name:        "greet",
+		Description: "Greet someone",
+		Arguments: []cli.Arg{
+			&cli.StringArg{
+				Name:     "name",
+				Required: true,
+			},
+		},
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "greeting",
+				Value:   "Hello",
+				EnvVars: []string{"APP_GREETING"},
+			},
+		},
+		Run: func(io *cli.IO) {
+			io.Ok(io.String("greeting") + " " + io.String("name"))
+		},
+	})
+
+	app.AddCommand(&cli.Command{
+		Name: "echo",
+		RunE: func(i *cli.IO) error {
+			content, err := io.ReadAll(i.Input.Stream)
+			i.Write(strings.ToUpper(string(content)))
+			return err
+		},
+	})
+
+	return app
+}
+
+func TestCommandTesterExecutesCommand(t *testing.T) {
+	app := newApplication()
+	tester := NewCommandTester(app.Subcommand("greet"))
+
+	code, err := tester.Execute([]string{"world"}, nil)
+	if code != 0 || err != nil {
+		t.Fatalf("expected success, got code %d and error %v", code, err)
+	}
+
+	if !strings.Contains(tester.Display(), "Hello world") {
+		t.Errorf("unexpected display %q", tester.Display())
+	}
+
+	if strings.Contains(tester.Display(), "\x1b[") {
+		t.Errorf("expected plain output, got %q", tester.Display())
+	}
+
+	tester.AssertGolden(t, "greet")
+}
+
+func TestCommandTesterCapturesDecoratedOutput(t *testing.T) {
+	tester := NewCommandTester(newApplication().Subcommand("greet"))
+
+	tester.Execute([]string{"world"}, &Options{Decorated: true})
+
+	if !strings.Contains(tester.Display(), "\x1b[") {
+		t.Errorf("expected decorated output, got %q", tester.Display())
+	}
+
+	tester.AssertGolden(t, "greet")
+}
+
+func TestCommandTesterSetsEnv(t *testing.T) {
+	tester := NewCommandTester(newApplication().Subcommand("greet"))
+
+	tester.Execute([]string{"world"}, &Options{Env: map[string]string{"APP_GREETING": "Hi"}})
+
+	if !strings.Contains(tester.Display(), "Hi world") {
+		t.Errorf("unexpected display %q", tester.Display())
+	}
+}
+
+func TestApplicationTesterReturnsExitCode(t *testing.T) {
+	tester := NewApplicationTester(newApplication())
+
+	code, err := tester.Execute([]string{"greet"}, nil)
+	if code != cli.ExitUsage || err == nil {
+		t.Errorf("expected usage error, got code %d and error %v", code, err)
+	}
+
+	code, err = tester.Execute([]string{"unknown"}, nil)
+	if code != cli.ExitUsage || err == nil {
+		t.Errorf("expected usage error, got code %d and error %v", code, err)
+	}
+}
+
+func TestApplicationTesterWritesInputsToStdin(t *testing.T) {
+	tester := NewApplicationTester(newApplication())
+
+	tester.Execute([]string{"echo"}, &Options{Inputs: []string{"foo", "bar"}})
+
+	if tester.Display() != "FOO\nBAR\n" {
+		t.Errorf("unexpected display %q", tester.Display())
+	}
+}
+
+type labelsValue struct {
+	labels  []string
+	changed bool
+}
+
+func (v *labelsValue) Set(s string) error {
+	if !v.changed {
+		v.labels = nil
+		v.changed = true
+	}
+
+	v.labels = append(v.labels, s)
+	return nil
+}
+
+func (v *labelsValue) String() string {
+	return strings.Join(v.labels, ",")
+}
+
+func (v *labelsValue) Type() string {
+	return "labels"
+}
+
+func (v *labelsValue) IsSlice() bool {
+	return true
+}
+
+func (v *labelsValue) GetSlice() []string {
+	return v.labels
+}
+
+func (v *labelsValue) Replace(labels []string) error {
+	v.labels = slices.Clone(labels)
+	v.changed = false
+	return nil
+}
+
+func TestCommandTesterCanBeReused(t *testing.T) {
+	labels := &labelsValue{labels: []string{"team=web"}}
+
+	app := newApplication()
+	app.AddCommand(&cli.Command{
+		Name: "deploy",
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: "region", Value: "eu"},
+			&cli.ArrayFlag{Name: "tag", Value: []string{"latest"}},
+			&cli.ValueFlag{Name: "label", Value: labels},
+		},
+		Run: func(io *cli.IO) {
+			io.Writeln(io.String("region") + " " + strings.Join(io.Array("tag"), ",") + " " + labels.String())
+		},
+	})
+
+	tester := NewCommandTester(app.Subcommand("deploy"))
+
+	tests := []struct {
+		args     []string
+		expected string
+	}{
+		{[]string{"--region=us", "--tag=a", "--label=env=prod"}, "us a env=prod\n"},
+		{[]string{"--tag=b", "--label=env=dev"}, "eu b env=dev\n"},
+		{nil, "eu latest team=web\n"},
+	}
+
+	for _, test := range tests {
+		if _, err := tester.Execute(test.args, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if tester.Display() != test.expected {
+			t.Errorf("expected %q for %v, got %q", test.expected, test.args, tester.Display())
+		}
+	}
+}
+
+func TestGoldenOutputDoesNotDependOnPadding(t *testing.T) {
+	app := newApplication()
+	app.AddCommand(&cli.Command{
+		Name: "deploy",
+		Run: func(io *cli.IO) {
+			io.Success("Deployed")
+			io.Output.Note("Restart the workers")
+		},
+	})
+
+	displays := make([]string, 0, 2)
+	for _, width := range []int{60, 120} {
+		tester := NewApplicationTester(app)
+		tester.Execute([]string{"deploy"}, &Options{Width: width})
+		tester.AssertGolden(t, "deploy")
+		displays = append(displays, tester.Display())
+	}
+
+	if displays[0] == displays[1] {
+		t.Errorf("expected the blocks to be padded to the width, got %q", displays[0])
+	}
+}
+
+func TestTesterRendersForDefaultSize(t *testing.T) {
+	app := newApplication()
+	app.AddCommand(&cli.Command{
+		Name: "size",
+		Run: func(io *cli.IO) {
+			io.Writelnf("%dx%d", io.Output.Width(), io.Output.Height())
+		},
+	})
+
+	tester := NewApplicationTester(app)
+
+	tester.Execute([]string{"size"}, nil)
+	if tester.Display() != "80x24\n" {
+		t.Errorf("expected the default size, got %q", tester.Display())
+	}
+
+	tester.Execute([]string{"size"}, &Options{Width: 100, Height: 30})
+	if tester.Display() != "100x30\n" {
+		t.Errorf("expected the given size, got %q", tester.Display())
+	}
+}
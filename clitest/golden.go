@@ -0,0 +1,62 @@
+package clitest
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// UpdateEnv is the environment variable that, when set, makes golden assertions write
+// the actual output to the golden files instead of comparing it.
+const UpdateEnv = "UPDATE_GOLDEN"
+
+var escapeSequence = regexp.MustCompile(`\x1b\[[0-9;?]*[A-Za-z]|\x1b\][^\x07\x1b]*(?:\x07|\x1b\\)`)
+
+// Normalize removes ANSI escape sequences, carriage returns and trailing whitespace
+// from the output, so that it does not depend on decoration, nor on the padding that
+// fills blocks to the width of the terminal. Output that is wrapped or drawn to the
+// width, like boxes and tables, still depends on the size that testers render for,
+// see Options, so golden files are written for that size.
+func Normalize(output string) string {
+	output = escapeSequence.ReplaceAllString(output, "")
+	output = strings.ReplaceAll(output, "\r\n", "\n")
+
+	lines := strings.Split(output, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t\r")
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// AssertGolden compares the normalized output with the golden file "testdata/<name>.golden".
+// The golden file is written instead when the UpdateEnv environment variable is set.
+func AssertGolden(tb testing.TB, name string, output string) {
+	tb.Helper()
+
+	path := filepath.Join("testdata", name+".golden")
+	actual := Normalize(output)
+
+	if os.Getenv(UpdateEnv) != "" {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			tb.Fatal(err)
+		}
+
+		if err := os.WriteFile(path, []byte(actual), 0644); err != nil {
+			tb.Fatal(err)
+		}
+
+		return
+	}
+
+	expected, err := os.ReadFile(path)
+	if err != nil {
+		tb.Fatalf("unable to read golden file \"%s\", run the tests with %s=1 to create it: %s", path, UpdateEnv, err)
+	}
+
+	if string(expected) != actual {
+		tb.Errorf("output does not match golden file \"%s\"\n--- expected\n%s\n--- actual\n%s", path, expected, actual)
+	}
+}
@@ -0,0 +1,453 @@
+package clitest
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/michielnijenhuis/cli/helper"
+)
+
+// Attributes are the graphic attributes of a cell, as set by SGR escape sequences.
+// Colors are the SGR parameters that set them, e.g. "31", "92" or "38;5;208", and
+// empty for the default color.
+type Attributes struct {
+	Foreground    string
+	Background    string
+	Bold          bool
+	Dim           bool
+	Italic        bool
+	Underline     bool
+	Blink         bool
+	Reverse       bool
+	Conceal       bool
+	Strikethrough bool
+}
+
+// Cell is a single character on the screen. The second cell of a wide character has
+// no rune.
+type Cell struct {
+	Rune rune
+	Attributes
+}
+
+// Screen is an in-memory VT100 screen. It interprets the escape sequences that this
+// library emits: cursor movement, erasing, scrolling, saving the cursor position and
+// graphic attributes. Other sequences are ignored.
+type Screen struct {
+	Width         int
+	Height        int
+	mu            sync.Mutex
+	cells         [][]Cell
+	row           int
+	column        int
+	wrapNext      bool
+	attributes    Attributes
+	savedRow      int
+	savedColumn   int
+	cursorVisible bool
+	pending       []byte
+	frames        []string
+	frameRow      int
+}
+
+func NewScreen(width int, height int) *Screen {
+	s := &Screen{
+		Width:         max(1, width),
+		Height:        max(1, height),
+		cursorVisible: true,
+		frameRow:      -1,
+	}
+
+	s.cells = make([][]Cell, s.Height)
+	for i := range s.cells {
+		s.cells[i] = s.blankRow()
+	}
+
+	return s
+}
+
+// Write interprets the bytes as terminal output. Escape sequences and characters may
+// be split across writes.
+func (s *Screen) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data := append(s.pending, p...)
+	s.pending = nil
+
+	for len(data) > 0 {
+		n := s.consume(data)
+		if n == 0 {
+			s.pending = append([]byte{}, data...)
+			break
+		}
+
+		data = data[n:]
+	}
+
+	return len(p), nil
+}
+
+// Lines returns the text of every row of the screen, without trailing whitespace.
+func (s *Screen) Lines() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.lines()
+}
+
+func (s *Screen) lines() []string {
+	lines := make([]string, 0, s.Height)
+	for _, row := range s.cells {
+		var line strings.Builder
+		for _, cell := range row {
+			if cell.Rune != 0 {
+				line.WriteRune(cell.Rune)
+			}
+		}
+
+		lines = append(lines, strings.TrimRight(line.String(), " "))
+	}
+
+	return lines
+}
+
+// String returns the text of the screen, without trailing whitespace and empty rows.
+func (s *Screen) String() string {
+	return strings.TrimRight(strings.Join(s.Lines(), "\n"), "\n")
+}
+
+// Cell returns the cell at the given zero-based row and column.
+func (s *Screen) Cell(row int, column int) Cell {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if row < 0 || row >= s.Height || column < 0 || column >= s.Width {
+		return Cell{}
+	}
+
+	return s.cells[row][column]
+}
+
+// Cursor returns the zero-based row and column of the cursor.
+func (s *Screen) Cursor() (int, int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.row, s.column
+}
+
+func (s *Screen) CursorVisible() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.cursorVisible
+}
+
+// Frames returns the frames that were redrawn on the screen, in order. Redrawn output,
+// such as a prompt, is erased from the cursor down before every frame, so a frame is
+// the text from the row of that erase down, as it was shown until it was erased. The
+// last frame is the text that is currently shown below the last erase.
+func (s *Screen) Frames() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.frameRow < 0 {
+		return append([]string{}, s.frames...)
+	}
+
+	return appendFrame(append([]string{}, s.frames...), s.text(s.frameRow))
+}
+
+// text returns the text from the given row down, without trailing empty rows.
+func (s *Screen) text(row int) string {
+	return strings.TrimRight(strings.Join(s.lines()[row:], "\n"), "\n")
+}
+
+func appendFrame(frames []string, frame string) []string {
+	if frame == "" || (len(frames) > 0 && frames[len(frames)-1] == frame) {
+		return frames
+	}
+
+	return append(frames, frame)
+}
+
+func (s *Screen) consume(data []byte) int {
+	switch data[0] {
+	case '\x1b':
+		return s.consumeEscape(data)
+	case '\n':
+		// output processing of terminals turns a line feed into a carriage return and
+		// a line feed
+		s.column = 0
+		s.lineFeed()
+	case '\r':
+		s.column = 0
+		s.wrapNext = false
+	case '\b':
+		s.column = max(0, s.column-1)
+		s.wrapNext = false
+	case '\t':
+		s.column = min(s.Width-1, (s.column/8+1)*8)
+	case '\a':
+	default:
+		if data[0] < 0x20 || data[0] == 0x7f {
+			return 1
+		}
+
+		if !utf8.FullRune(data) {
+			return 0
+		}
+
+		r, size := utf8.DecodeRune(data)
+		s.put(r)
+		return size
+	}
+
+	return 1
+}
+
+func (s *Screen) consumeEscape(data []byte) int {
+	if len(data) < 2 {
+		return 0
+	}
+
+	switch data[1] {
+	case '[':
+		for i := 2; i < len(data); i++ {
+			if data[i] >= 0x40 && data[i] <= 0x7e {
+				s.csi(string(data[2:i]), data[i])
+				return i + 1
+			}
+		}
+
+		return 0
+	case ']':
+		for i := 2; i < len(data); i++ {
+			if data[i] == '\a' {
+				return i + 1
+			}
+
+			if data[i] == '\x1b' {
+				if i+1 >= len(data) {
+					return 0
+				}
+
+				return i + 2
+			}
+		}
+
+		return 0
+	case '7':
+		s.savedRow, s.savedColumn = s.row, s.column
+	case '8':
+		s.row, s.column = s.savedRow, s.savedColumn
+		s.wrapNext = false
+	}
+
+	return 2
+}
+
+func (s *Screen) csi(params string, final byte) {
+	if strings.HasPrefix(params, "?") {
+		switch params {
+		case "?25l":
+			s.cursorVisible = false
+		case "?25h":
+			s.cursorVisible = true
+		}
+
+		return
+	}
+
+	args := strings.Split(params, ";")
+	arg := func(i int, def int) int {
+		if i >= len(args) || args[i] == "" {
+			return def
+		}
+
+		n, err := strconv.Atoi(args[i])
+		if err != nil {
+			return def
+		}
+
+		return n
+	}
+
+	s.wrapNext = false
+
+	switch final {
+	case 'A':
+		s.row = max(0, s.row-max(1, arg(0, 1)))
+	case 'B':
+		s.row = min(s.Height-1, s.row+max(1, arg(0, 1)))
+	case 'C':
+		s.column = min(s.Width-1, s.column+max(1, arg(0, 1)))
+	case 'D':
+		s.column = max(0, s.column-max(1, arg(0, 1)))
+	case 'G':
+		s.column = min(s.Width-1, max(1, arg(0, 1))-1)
+	case 'H', 'f':
+		s.row = min(s.Height-1, max(1, arg(0, 1))-1)
+		s.column = min(s.Width-1, max(1, arg(1, 1))-1)
+	case 'J':
+		s.eraseDisplay(arg(0, 0))
+	case 'K':
+		s.eraseLine(arg(0, 0))
+	case 'm':
+		s.sgr(args)
+	}
+}
+
+func (s *Screen) eraseDisplay(mode int) {
+	switch mode {
+	case 0:
+		s.frames = appendFrame(s.frames, s.text(s.row))
+		s.frameRow = s.row
+		s.eraseLine(0)
+		for row := s.row + 1; row < s.Height; row++ {
+			s.cells[row] = s.blankRow()
+		}
+	case 1:
+		s.eraseLine(1)
+		for row := 0; row < s.row; row++ {
+			s.cells[row] = s.blankRow()
+		}
+	case 2, 3:
+		for row := range s.cells {
+			s.cells[row] = s.blankRow()
+		}
+	}
+}
+
+func (s *Screen) eraseLine(mode int) {
+	from, to := s.column, s.Width
+	switch mode {
+	case 1:
+		from, to = 0, s.column+1
+	case 2:
+		from = 0
+	}
+
+	for column := from; column < to && column < s.Width; column++ {
+		s.cells[s.row][column] = Cell{Rune: ' '}
+	}
+}
+
+func (s *Screen) sgr(args []string) {
+	for i := 0; i < len(args); i++ {
+		code, err := strconv.Atoi(args[i])
+		if err != nil {
+			code = 0
+		}
+
+		switch {
+		case code == 0:
+			s.attributes = Attributes{}
+		case code == 1:
+			s.attributes.Bold = true
+		case code == 2:
+			s.attributes.Dim = true
+		case code == 3:
+			s.attributes.Italic = true
+		case code == 4:
+			s.attributes.Underline = true
+		case code == 5:
+			s.attributes.Blink = true
+		case code == 7:
+			s.attributes.Reverse = true
+		case code == 8:
+			s.attributes.Conceal = true
+		case code == 9:
+			s.attributes.Strikethrough = true
+		case code == 22:
+			s.attributes.Bold = false
+			s.attributes.Dim = false
+		case code == 23:
+			s.attributes.Italic = false
+		case code == 24:
+			s.attributes.Underline = false
+		case code == 25:
+			s.attributes.Blink = false
+		case code == 27:
+			s.attributes.Reverse = false
+		case code == 28:
+			s.attributes.Conceal = false
+		case code == 29:
+			s.attributes.Strikethrough = false
+		case code >= 30 && code <= 37, code >= 90 && code <= 97:
+			s.attributes.Foreground = args[i]
+		case code == 39:
+			s.attributes.Foreground = ""
+		case code >= 40 && code <= 47, code >= 100 && code <= 107:
+			s.attributes.Background = args[i]
+		case code == 49:
+			s.attributes.Background = ""
+		case code == 38, code == 48:
+			n := 0
+			if i+1 < len(args) && args[i+1] == "5" {
+				n = 2
+			} else if i+1 < len(args) && args[i+1] == "2" {
+				n = 4
+			}
+
+			color := strings.Join(args[i:min(len(args), i+n+1)], ";")
+			if code == 38 {
+				s.attributes.Foreground = color
+			} else {
+				s.attributes.Background = color
+			}
+
+			i += n
+		}
+	}
+}
+
+func (s *Screen) put(r rune) {
+	width := helper.Width(string(r))
+	if width == 0 {
+		return
+	}
+
+	if s.wrapNext || s.column+width > s.Width {
+		s.column = 0
+		s.lineFeed()
+	}
+
+	s.cells[s.row][s.column] = Cell{Rune: r, Attributes: s.attributes}
+	if width == 2 && s.column+1 < s.Width {
+		s.cells[s.row][s.column+1] = Cell{Attributes: s.attributes}
+	}
+
+	if s.column+width >= s.Width {
+		s.column = s.Width - 1
+		s.wrapNext = true
+	} else {
+		s.column += width
+	}
+}
+
+func (s *Screen) lineFeed() {
+	s.wrapNext = false
+
+	if s.row < s.Height-1 {
+		s.row++
+		return
+	}
+
+	s.cells = append(s.cells[1:], s.blankRow())
+	if s.frameRow > 0 {
+		s.frameRow--
+	}
+}
+
+func (s *Screen) blankRow() []Cell {
+	row := make([]Cell, s.Width)
+	for i := range row {
+		row[i] = Cell{Rune: ' '}
+	}
+
+	return row
+}
@@ -0,0 +1,135 @@
+package clitest
+
+import (
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/michielnijenhuis/cli"
+	"github.com/michielnijenhuis/cli/helper/keys"
+)
+
+func TestScreenMovesCursorAndErases(t *testing.T) {
+	s := NewScreen(20, 5)
+	s.Write([]byte("hello\nworld\nagain"))
+	s.Write([]byte("\x1b[1A\x1b[3G\x1b[K!\x1b[1B\x1b[1G\x1b[2K"))
+
+	expected := []string{"hello", "wo!", "", "", ""}
+	if lines := s.Lines(); !slices.Equal(lines, expected) {
+		t.Errorf("expected lines %q, got %q", expected, lines)
+	}
+
+	s.Write([]byte("\x1b[1;1H\x1b[0J"))
+	if s.String() != "" {
+		t.Errorf("expected an empty screen, got %q", s.String())
+	}
+}
+
+func TestScreenWrapsAndScrolls(t *testing.T) {
+	s := NewScreen(4, 2)
+	s.Write([]byte("abcdefgh\nij"))
+
+	expected := []string{"efgh", "ij"}
+	if lines := s.Lines(); !slices.Equal(lines, expected) {
+		t.Errorf("expected lines %q, got %q", expected, lines)
+	}
+}
+
+func TestScreenTracksAttributes(t *testing.T) {
+	s := NewScreen(20, 2)
+	s.Write([]byte("\x1b[31;1mred\x1b[39;22m \x1b[38;5;208;48;2;0;0;255mx\x1b[0m"))
+
+	if cell := s.Cell(0, 0); cell.Rune != 'r' || cell.Foreground != "31" || !cell.Bold {
+		t.Errorf("unexpected cell %+v", cell)
+	}
+
+	if cell := s.Cell(0, 3); cell.Foreground != "" || cell.Bold {
+		t.Errorf("unexpected cell %+v", cell)
+	}
+
+	if cell := s.Cell(0, 4); cell.Foreground != "38;5;208" || cell.Background != "48;2;0;0;255" {
+		t.Errorf("unexpected cell %+v", cell)
+	}
+}
+
+func TestScreenHandlesSequencesSplitAcrossWrites(t *testing.T) {
+	s := NewScreen(10, 2)
+	s.Write([]byte("abc\x1b["))
+	s.Write([]byte("2Dé"[:3]))
+	s.Write([]byte("2Dé"[3:]))
+
+	if s.String() != "aéc" {
+		t.Errorf("expected \"aéc\", got %q", s.String())
+	}
+}
+
+func TestScreenShowsRedrawnView(t *testing.T) {
+	term := NewTerminal(40, 10)
+	term.Run(func(i *cli.Input, o *cli.Output) {
+		view := cli.NewView(o)
+		view.Render("one\ntwo\n")
+		view.Render("three\n")
+		view.Render("four\nfive\n")
+	})
+
+	expected := []string{"four", "five"}
+	if lines := term.Screen().Lines()[:2]; !slices.Equal(lines, expected) {
+		t.Errorf("expected lines %q, got %q", expected, term.Screen().Lines())
+	}
+}
+
+func TestScreenShowsSubmittedPrompt(t *testing.T) {
+	term := NewTerminal(40, 10).Type("Jane").Press(keys.Enter)
+	term.Run(func(i *cli.Input, o *cli.Output) {
+		cli.NewTextPrompt(i, o, "Name", "").Render()
+	})
+
+	if s := term.Screen().String(); s != "? Name Jane" {
+		t.Errorf("unexpected screen %q", s)
+	}
+
+	if !term.Screen().CursorVisible() {
+		t.Errorf("expected the cursor to be visible after the prompt")
+	}
+}
+
+func TestScreenShowsMultiProgressUpdatedConcurrently(t *testing.T) {
+	term := NewTerminal(60, 10)
+	term.Run(func(i *cli.Input, o *cli.Output) {
+		o.SetDecorated(true)
+		m := cli.NewMultiProgress(o)
+		m.Interval = time.Millisecond
+
+		bars := []*cli.ProgressBar{m.AddBar(20), m.AddBar(20), m.AddBar(20)}
+		spinner := m.AddSpinner("Waiting")
+
+		var wg sync.WaitGroup
+		for _, bar := range bars {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for range 20 {
+					bar.Advance(1)
+				}
+			}()
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			spinner.SetMessage("Working")
+		}()
+
+		wg.Wait()
+		spinner.Finish("Done")
+		m.Finish()
+	})
+
+	bar := " 20/20 [" + strings.Repeat("▓", 28) + "] 100%"
+	expected := []string{bar, bar, bar, cli.IconTickSwoosh + " Done"}
+	if lines := term.Screen().Lines()[:4]; !slices.Equal(lines, expected) {
+		t.Errorf("expected lines %q, got %q", expected, term.Screen().Lines())
+	}
+}
@@ -0,0 +1,129 @@
+package clitest
+
+import (
+	"bytes"
+	"io"
+	"sync"
+
+	"github.com/michielnijenhuis/cli"
+)
+
+// Terminal is a fake terminal for testing interactive prompts. Prompts read the queued
+// keys one at a time. The output is interpreted by a Screen of the size of the
+// terminal, which records every frame that prompts render.
+type Terminal struct {
+	Width  int
+	Height int
+	mu     sync.Mutex
+	keys   []string
+	output bytes.Buffer
+	screen *Screen
+}
+
+func NewTerminal(width int, height int) *Terminal {
+	return &Terminal{
+		Width:  width,
+		Height: height,
+	}
+}
+
+// Press queues the given keys, e.g. keys.Down or keys.Enter.
+func (t *Terminal) Press(keys ...string) *Terminal {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.keys = append(t.keys, keys...)
+	return t
+}
+
+// Type queues a key press for every character of the text.
+func (t *Terminal) Type(text string) *Terminal {
+	keys := make([]string, 0, len(text))
+	for _, r := range text {
+		keys = append(keys, string(r))
+	}
+
+	return t.Press(keys...)
+}
+
+// Read reads the next queued key. It returns io.EOF when no keys are left.
+func (t *Terminal) Read(p []byte) (int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if len(t.keys) == 0 {
+		return 0, io.EOF
+	}
+
+	n := copy(p, t.keys[0])
+	if n < len(t.keys[0]) {
+		t.keys[0] = t.keys[0][n:]
+	} else {
+		t.keys = t.keys[1:]
+	}
+
+	return n, nil
+}
+
+func (t *Terminal) Write(p []byte) (int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.lazyScreen().Write(p)
+	return t.output.Write(p)
+}
+
+// Screen returns the screen that shows the output written to the terminal.
+func (t *Terminal) Screen() *Screen {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.lazyScreen()
+}
+
+func (t *Terminal) lazyScreen() *Screen {
+	if t.screen == nil {
+		t.screen = NewScreen(t.Width, t.Height)
+	}
+
+	return t.screen
+}
+
+func (t *Terminal) IsTerminal() bool {
+	return true
+}
+
+func (t *Terminal) Size() (int, int) {
+	return t.Width, t.Height
+}
+
+// Frames returns the frames rendered by prompts, in order, see Screen.Frames.
+func (t *Terminal) Frames() []string {
+	return t.Screen().Frames()
+}
+
+// LastFrame returns the last frame rendered by a prompt.
+func (t *Terminal) LastFrame() string {
+	frames := t.Frames()
+	if len(frames) == 0 {
+		return ""
+	}
+
+	return frames[len(frames)-1]
+}
+
+// Output returns everything written to the terminal, including escape sequences.
+func (t *Terminal) Output() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.output.String()
+}
+
+// Run calls fn with an interactive input and an output attached to the terminal.
+func (t *Terminal) Run(fn func(i *cli.Input, o *cli.Output)) {
+	i := cli.NewInput([]string{}...)
+	i.SetStream(t)
+
+	fn(i, cli.NewStreamOutput(i, t, t))
+}
@@ -0,0 +1,130 @@
+package clitest
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/michielnijenhuis/cli"
+	"github.com/michielnijenhuis/cli/helper/keys"
+)
+
+func TestTerminalRecordsFramesOfTextPrompt(t *testing.T) {
+	term := NewTerminal(80, 24).Type("Jane").Press(keys.Backspace, keys.Enter)
+
+	var answer string
+	var err error
+	term.Run(func(i *cli.Input, o *cli.Output) {
+		answer, err = cli.NewTextPrompt(i, o, "What is your name?", "").Render()
+	})
+
+	if err != nil || answer != "Jan" {
+		t.Fatalf("expected answer \"Jan\", got %q and error %v", answer, err)
+	}
+
+	frames := term.Frames()
+	if len(frames) != 7 {
+		t.Fatalf("expected 7 frames, got %d", len(frames))
+	}
+
+	if frame := frames[4]; frame != "? What is your name?\n› Jane" {
+		t.Errorf("unexpected frame %q", frame)
+	}
+
+	if frame := term.LastFrame(); frame != "? What is your name? Jan" {
+		t.Errorf("unexpected last frame %q", frame)
+	}
+}
+
+func TestTerminalAnswersSelectPrompt(t *testing.T) {
+	term := NewTerminal(80, 24).Press(keys.Down, keys.Down, keys.Up, keys.Enter)
+
+	var answer string
+	var err error
+	term.Run(func(i *cli.Input, o *cli.Output) {
+		answer, err = cli.NewSelectPrompt(i, o, "Region", []string{"eu", "us", "ap"}, nil, "").Render()
+	})
+
+	if err != nil || answer != "us" {
+		t.Errorf("expected answer \"us\", got %q and error %v", answer, err)
+	}
+}
+
+func TestTerminalFailsWhenKeysRunOut(t *testing.T) {
+	term := NewTerminal(80, 24).Type("abc")
+
+	var err error
+	term.Run(func(i *cli.Input, o *cli.Output) {
+		_, err = cli.NewTextPrompt(i, o, "Name", "").Render()
+	})
+
+	if err == nil {
+		t.Errorf("expected an error when the keys run out")
+	}
+}
+
+func TestTesterExecutesCommandInTerminal(t *testing.T) {
+	app := &cli.Command{Name: "app"}
+	app.AddCommand(&cli.Command{
+		Name: "deploy",
+		RunE: func(io *cli.IO) error {
+			ok, err := io.Confirm("Continue?", false)
+			if ok {
+				io.Writeln("Deploying")
+			}
+
+			return err
+		},
+	})
+
+	term := NewTerminal(60, 20).Press(keys.Left, keys.Enter)
+	tester := NewApplicationTester(app)
+
+	code, err := tester.Execute([]string{"deploy"}, &Options{Terminal: term})
+	if code != 0 || err != nil {
+		t.Fatalf("expected success, got code %d and error %v", code, err)
+	}
+
+	if frame := term.LastFrame(); frame != "? Continue? yes\nDeploying" {
+		t.Errorf("unexpected last frame %q", frame)
+	}
+
+	if display := Normalize(tester.Display()); !strings.HasSuffix(display, "Deploying\n") {
+		t.Errorf("unexpected display %q", display)
+	}
+}
+
+func TestTerminalSizeIsTakenFromTheTerminal(t *testing.T) {
+	var width, height int
+	app := &cli.Command{
+		Name: "app",
+		Run: func(io *cli.IO) {
+			width, height = io.Output.Width(), io.Output.Height()
+		},
+	}
+
+	NewApplicationTester(app).Execute(nil, &Options{Terminal: NewTerminal(100, 30)})
+	if width != 100 || height != 30 {
+		t.Errorf("expected a size of 100x30, got %dx%d", width, height)
+	}
+}
+
+func TestTerminalInterruptsPromptOnCtrlC(t *testing.T) {
+	term := NewTerminal(80, 24).Type("Jane").Press(keys.CtrlC)
+
+	var err, cause error
+	term.Run(func(i *cli.Input, o *cli.Output) {
+		i.SetContext(context.Background())
+		_, err = cli.NewTextPrompt(i, o, "Name", "").Render()
+		cause = context.Cause(i.Context())
+	})
+
+	if !errors.Is(err, cli.ErrInterrupted) {
+		t.Errorf("expected the prompt to be interrupted, got %v", err)
+	}
+
+	if !errors.Is(cause, cli.ErrInterrupted) {
+		t.Errorf("expected the context to be interrupted, got %v", cause)
+	}
+}
@@ -0,0 +1,235 @@
+package cli
+
+import (
+	"encoding/xml"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// commandDescription is the machine readable description of a command, shared by the
+// JSON, XML and Markdown descriptors.
+type commandDescription struct {
+	XMLName     xml.Name              `json:"-" xml:"command"`
+	Name        string                `json:"name" xml:"name,attr"`
+	FullName    string                `json:"full_name" xml:"full_name,attr"`
+	Hidden      bool                  `json:"hidden" xml:"hidden,attr"`
+	Deprecated  string                `json:"deprecated,omitempty" xml:"deprecated,attr,omitempty"`
+	Group       string                `json:"group,omitempty" xml:"group,attr,omitempty"`
+	Aliases     []string              `json:"aliases" xml:"aliases>alias"`
+	Usage       []string              `json:"usage" xml:"usages>usage"`
+	Description string                `json:"description" xml:"description"`
+	Help        string                `json:"help" xml:"help"`
+	Definition  definitionDescription `json:"definition" xml:"definition"`
+	Commands    []commandDescription  `json:"commands,omitempty" xml:"commands>command"`
+}
+
+type definitionDescription struct {
+	XMLName    xml.Name               `json:"-" xml:"definition"`
+	Arguments  []argumentDescription  `json:"arguments" xml:"arguments>argument"`
+	Flags      []flagDescription      `json:"flags" xml:"flags>flag"`
+	FlagGroups []flagGroupDescription `json:"flag_groups,omitempty" xml:"flag_groups>flag_group"`
+}
+
+type argumentDescription struct {
+	XMLName     xml.Name `json:"-" xml:"argument"`
+	Name        string   `json:"name" xml:"name,attr"`
+	IsRequired  bool     `json:"is_required" xml:"is_required,attr"`
+	IsArray     bool     `json:"is_array" xml:"is_array,attr"`
+	Description string   `json:"description" xml:"description"`
+	Default     any      `json:"default" xml:"-"`
+	Defaults    []string `json:"-" xml:"defaults>default"`
+	Options     []string `json:"options,omitempty" xml:"options>option"`
+}
+
+type flagDescription struct {
+	XMLName         xml.Name `json:"-" xml:"flag"`
+	Name            string   `json:"name" xml:"name,attr"`
+	Shortcuts       []string `json:"shortcuts" xml:"-"`
+	Shortcut        string   `json:"-" xml:"shortcut,attr"`
+	AcceptValue     bool     `json:"accept_value" xml:"accept_value,attr"`
+	IsValueRequired bool     `json:"is_value_required" xml:"is_value_required,attr"`
+	IsMultiple      bool     `json:"is_multiple" xml:"is_multiple,attr"`
+	IsNegatable     bool     `json:"is_negatable" xml:"is_negatable,attr"`
+	IsRequired      bool     `json:"is_required" xml:"is_required,attr"`
+	IsGlobal        bool     `json:"is_global" xml:"is_global,attr"`
+	Deprecated      string   `json:"deprecated,omitempty" xml:"deprecated,attr,omitempty"`
+	ReplacedBy      string   `json:"replaced_by,omitempty" xml:"replaced_by,attr,omitempty"`
+	Description     string   `json:"description" xml:"description"`
+	Default         any      `json:"default" xml:"-"`
+	Defaults        []string `json:"-" xml:"defaults>default"`
+	Options         []string `json:"options,omitempty" xml:"options>option"`
+	EnvVars         []string `json:"env_vars,omitempty" xml:"env_vars>env_var"`
+}
+
+type flagGroupDescription struct {
+	XMLName     xml.Name `json:"-" xml:"flag_group"`
+	Description string   `json:"description" xml:"description,attr"`
+	Flags       []string `json:"flags" xml:"flag"`
+}
+
+func describeCommand(command *Command, formatter *OutputFormatter) commandDescription {
+	definition, err := command.Definition()
+	if err != nil {
+		definition = &InputDefinition{}
+	}
+
+	inheritedFlags, _ := command.InheritedFlags()
+
+	usage := make([]string, 0, 1+len(command.Usages()))
+	if command.Run != nil || command.RunE != nil || !command.HasSubcommands() {
+		usage = append(usage, command.Synopsis(true))
+	}
+	if command.HasSubcommands() {
+		usage = append(usage, command.FullName()+" [command]")
+	}
+	usage = append(usage, command.Usages()...)
+
+	aliases := command.Aliases
+	if aliases == nil {
+		aliases = []string{}
+	}
+
+	desc := commandDescription{
+		Name:        command.Name,
+		FullName:    command.FullName(),
+		Hidden:      command.Hidden,
+		Deprecated:  command.Deprecated,
+		Group:       command.Group,
+		Aliases:     aliases,
+		Usage:       usage,
+		Description: formatter.RemoveDecoration(command.Description),
+		Help:        formatter.RemoveDecoration(command.ProcessedHelp()),
+		Definition:  describeDefinition(definition, inheritedFlags, formatter),
+	}
+
+	for _, group := range command.GroupedSubcommands() {
+		for _, cmd := range group.Commands {
+			desc.Commands = append(desc.Commands, describeCommand(cmd, formatter))
+		}
+	}
+
+	return desc
+}
+
+func describeDefinition(definition *InputDefinition, globalFlags []Flag, formatter *OutputFormatter) definitionDescription {
+	desc := definitionDescription{
+		Arguments: make([]argumentDescription, 0, len(definition.arguments)),
+		Flags:     make([]flagDescription, 0, len(definition.flags)),
+	}
+
+	for _, argument := range definition.arguments {
+		desc.Arguments = append(desc.Arguments, describeArgument(argument, formatter))
+	}
+
+	for _, flag := range definition.flags {
+		f := describeFlag(flag, definition.EnvVars(flag.GetName()), formatter)
+		f.IsGlobal = slices.Contains(globalFlags, flag)
+		desc.Flags = append(desc.Flags, f)
+	}
+
+	for _, group := range definition.FlagGroups() {
+		desc.FlagGroups = append(desc.FlagGroups, flagGroupDescription{
+			Description: group.Description(),
+			Flags:       group.Flags,
+		})
+	}
+
+	return desc
+}
+
+func describeArgument(argument Arg, formatter *OutputFormatter) argumentDescription {
+	_, isArray := argument.(*ArrayArg)
+
+	var value any
+	if isArray {
+		value = GetArgArrayValue(argument)
+	} else if argHasDefaultValue(argument) {
+		value = describeValue(GetArgValue(argument), GetArgStringValue(argument))
+	}
+
+	return argumentDescription{
+		Name:        argument.GetName(),
+		IsRequired:  argument.IsRequired(),
+		IsArray:     isArray,
+		Description: formatter.RemoveDecoration(argument.GetDescription()),
+		Default:     value,
+		Defaults:    describeDefaults(value),
+		Options:     argument.Opts(),
+	}
+}
+
+func describeFlag(flag Flag, envVars []string, formatter *OutputFormatter) flagDescription {
+	shortcuts := make([]string, 0, len(flag.GetShortcuts()))
+	for _, shortcut := range flag.GetShortcuts() {
+		shortcuts = append(shortcuts, "-"+shortcut)
+	}
+
+	var value any
+	switch {
+	case FlagIsArray(flag):
+		value = GetFlagArrayValue(flag)
+	case !FlagAcceptsValue(flag):
+		value = GetFlagBoolValue(flag)
+	default:
+		value = describeValue(GetFlagValue(flag), GetFlagStringValue(flag))
+	}
+
+	var options []string
+	if f, ok := flag.(HasOptions); ok {
+		options = f.Opts()
+	}
+
+	return flagDescription{
+		Name:            "--" + flag.GetName(),
+		Shortcuts:       shortcuts,
+		Shortcut:        joinShortcuts(shortcuts),
+		AcceptValue:     FlagAcceptsValue(flag),
+		IsValueRequired: FlagRequiresValue(flag),
+		IsMultiple:      FlagIsArray(flag),
+		IsNegatable:     FlagIsNegatable(flag),
+		IsRequired:      FlagIsRequired(flag),
+		Deprecated:      flagDeprecation(flag),
+		ReplacedBy:      FlagReplacement(flag),
+		Description:     formatter.RemoveDecoration(flag.GetDescription()),
+		Default:         value,
+		Defaults:        describeDefaults(value),
+		Options:         options,
+		EnvVars:         envVars,
+	}
+}
+
+func flagDeprecation(flag Flag) string {
+	if f, ok := flag.(CanBeDeprecated); ok {
+		return f.GetDeprecated()
+	}
+
+	return ""
+}
+
+// describeValue converts values that have no natural JSON representation, such as
+// durations, times and custom values, into their string form.
+func describeValue(value InputType, str string) any {
+	switch value.(type) {
+	case time.Duration, time.Time, Value:
+		return str
+	default:
+		return value
+	}
+}
+
+func describeDefaults(value any) []string {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case []string:
+		return v
+	case string:
+		if v == "" {
+			return nil
+		}
+		return []string{v}
+	default:
+		return []string{fmt.Sprintf("%v", v)}
+	}
+}
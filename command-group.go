@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/michielnijenhuis/cli/helper/array"
+)
+
+// CommandGroup is a section of subcommands in the help output. Subcommands are added
+// to a group by setting their Group to the ID of the group.
+type CommandGroup struct {
+	ID    string
+	Title string
+}
+
+type GroupedCommands struct {
+	Group    CommandGroup
+	Commands []*Command
+}
+
+// GroupedSubcommands returns the visible subcommands sorted by name and divided into
+// groups. Ungrouped commands come first, in a group with an empty ID, followed by the
+// groups defined in Groups, in order. Commands that refer to an undefined group, or
+// that are named "namespace:name", are grouped by that group or namespace. Plugins of
+// the root command come last, in a group with the ID "plugins".
+func (c *Command) GroupedSubcommands() []GroupedCommands {
+	commands := c.All()
+	ungrouped := GroupedCommands{Commands: make([]*Command, 0)}
+	groups := make([]GroupedCommands, 0, len(c.Groups))
+	implicit := make([]GroupedCommands, 0)
+
+	for _, group := range c.Groups {
+		groups = append(groups, GroupedCommands{Group: group, Commands: make([]*Command, 0)})
+	}
+
+	for _, name := range array.SortedKeys(commands) {
+		cmd := commands[name]
+		if !cmd.IsVisible() {
+			continue
+		}
+
+		id := cmd.Group
+		if id == "" {
+			if ns, _, ok := strings.Cut(cmd.Name, ":"); ok {
+				id = ns
+			}
+		}
+
+		if id == "" {
+			ungrouped.Commands = append(ungrouped.Commands, cmd)
+			continue
+		}
+
+		idx := slices.IndexFunc(groups, func(g GroupedCommands) bool { return g.Group.ID == id })
+		if idx != -1 {
+			groups[idx].Commands = append(groups[idx].Commands, cmd)
+			continue
+		}
+
+		idx = slices.IndexFunc(implicit, func(g GroupedCommands) bool { return g.Group.ID == id })
+		if idx == -1 {
+			implicit = append(implicit, GroupedCommands{Group: CommandGroup{ID: id, Title: id}})
+			idx = len(implicit) - 1
+		}
+
+		implicit[idx].Commands = append(implicit[idx].Commands, cmd)
+	}
+
+	slices.SortFunc(implicit, func(a GroupedCommands, b GroupedCommands) int {
+		return strings.Compare(a.Group.ID, b.Group.ID)
+	})
+
+	plugins := GroupedCommands{Group: pluginGroup, Commands: c.rootPlugins()}
+
+	result := make([]GroupedCommands, 0, 2+len(groups)+len(implicit))
+	for _, group := range slices.Concat([]GroupedCommands{ungrouped}, groups, implicit, []GroupedCommands{plugins}) {
+		if len(group.Commands) > 0 {
+			result = append(result, group)
+		}
+	}
+
+	return result
+}
@@ -1,19 +1,28 @@
 package cli
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"io"
 	"os"
+	"os/signal"
 	"regexp"
 	"slices"
 	"sort"
 	"strconv"
 	"strings"
+	"syscall"
+	"time"
 
 	"github.com/michielnijenhuis/cli/helper/array"
-	"github.com/michielnijenhuis/cli/terminal"
 )
 
+// DefaultShutdownTimeout is the time a command is given to return after being
+// interrupted, when no ShutdownTimeout is set. A negative ShutdownTimeout waits
+// indefinitely.
+const DefaultShutdownTimeout = 10 * time.Second
+
 type CommandHandle func(io *IO)
 type CommandHandleE func(io *IO) error
 
@@ -23,21 +32,43 @@ type Command struct {
 	Version                string
 	LongVersion            string
 	Aliases                []string
+	DeprecatedAliases      []string
 	Help                   string
 	Commands               []*Command
+	Groups                 []CommandGroup
+	Group                  string
 	Flags                  []Flag
+	PersistentFlags        []Flag
+	FlagGroups             []FlagGroup
 	Arguments              []Arg
+	Options                any
 	Run                    CommandHandle
 	RunE                   CommandHandleE
+	PreRun                 CommandHandle
+	PreRunE                CommandHandleE
+	PostRun                CommandHandle
+	PostRunE               CommandHandleE
+	PersistentPreRun       CommandHandle
+	PersistentPreRunE      CommandHandleE
+	PersistentPostRun      CommandHandle
+	PersistentPostRunE     CommandHandleE
 	AutoExit               bool
 	CatchErrors            bool
 	Strict                 bool
 	IgnoreValidationErrors bool
 	Hidden                 bool
+	Deprecated             string
 	PromptForInput         bool
 	PrintHelpFunc          func(o *Output, command *Command)
+	CompleteFunc           CompletionFunc
 	NativeFlags            []string
 	CascadeNativeFlags     bool
+	EnvPrefix              string
+	ConfigName             string
+	ExitOnSecondSignal     bool
+	ShutdownTimeout        time.Duration
+	EnablePlugins          bool
+	PluginDir              string
 	definition             *InputDefinition
 	synopsis               map[string]string
 	usages                 []string
@@ -45,19 +76,45 @@ type Command struct {
 	commands               map[string]*Command
 	runningCommand         *Command
 	initialized            bool
+	initErr                error
 	validated              bool
 	input                  *Input
 	output                 *Output
+	optionFields           []optionField
+	calledAs               string
+	inStream               io.Reader
+	outStream              io.Writer
+	errStream              io.Writer
+	plugins                []*Command
 }
 
-func (c *Command) Execute(args ...string) (err error) {
-	width, height := terminal.Size()
-	os.Setenv("LINES", fmt.Sprint(height))
-	os.Setenv("COLUMNS", fmt.Sprint(width))
+func (c *Command) Execute(args ...string) (int, error) {
+	return c.ExecuteContext(context.Background(), args...)
+}
 
+// ExecuteContext executes the command with a context that is cancelled when the
+// process receives an interrupt or termination signal. The context is available
+// to the command through IO.Context(). It returns the exit code for the returned
+// error, see ExitCode.
+func (c *Command) ExecuteContext(ctx context.Context, args ...string) (int, error) {
 	i := NewInput(args...)
+	if c.inStream != nil {
+		i.SetStream(c.inStream)
+	}
+	o := NewStreamOutput(i, c.outOrStdout(), c.errOrStderr())
+
+	ctx, stopSignals := c.handleSignals(ctx, i)
+	defer stopSignals()
+
+	return c.ExecuteIO(ctx, i, o)
+}
+
+// ExecuteIO executes the command like ExecuteContext, with the given input and output
+// instead of the ones configured on the command. Signals are not handled, the command
+// is only interrupted when the given context is cancelled.
+func (c *Command) ExecuteIO(ctx context.Context, i *Input, o *Output) (code int, err error) {
 	i.Strict = c.Strict
-	o := NewOutput(i)
+	i.SetContext(ctx)
 
 	c.input = i
 	c.output = o
@@ -80,6 +137,7 @@ func (c *Command) Execute(args ...string) (err error) {
 				}
 
 				caughtError = true
+				code = ExitCode(err)
 				c.handleError(o, err)
 			}
 		}()
@@ -88,12 +146,19 @@ func (c *Command) Execute(args ...string) (err error) {
 	c.configureIO(i, o)
 
 	if c.HasSubcommands() {
-		c.InitDefaultCompletionCmd(o.Stream)
+		c.InitDefaultCompletionCmd()
+		c.InitDefaultHelpCmd()
+		c.InitDefaultListCmd()
+	}
+
+	if c.ConfigName != "" {
+		c.initConfigFlag()
 	}
 
-	c.initCompleteCmd(i.Args)
+	c.initCompleteCmd()
 
 	err = c.execute(i, o)
+	code = ExitCode(err)
 
 	if !caughtError {
 		c.handleError(o, err)
@@ -103,7 +168,7 @@ func (c *Command) Execute(args ...string) (err error) {
 }
 
 func (c *Command) handleError(o *Output, err error) {
-	if err != nil {
+	if err != nil && !errors.Is(err, ErrInterrupted) && !errors.Is(err, context.Canceled) && !isSilentExit(err) {
 		c.RenderError(o, err)
 	}
 
@@ -112,11 +177,60 @@ func (c *Command) handleError(o *Output, err error) {
 	}
 }
 
+// Exit exits the process with the exit code of the error, see ExitCode.
 func (c *Command) Exit(err error) {
-	if err != nil {
-		os.Exit(1)
-	} else {
-		os.Exit(0)
+	os.Exit(ExitCode(err))
+}
+
+// handleSignals returns a context that is cancelled on the first interrupt or
+// termination signal. The process is exited when the shutdown timeout expires, or on
+// a second signal if ExitOnSecondSignal is set. The returned function stops the
+// handling.
+func (c *Command) handleSignals(ctx context.Context, i *Input) (context.Context, func()) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	sigs := make(chan os.Signal, 2)
+	signal.Notify(sigs, os.Interrupt, syscall.SIGTERM)
+	done := make(chan struct{})
+
+	go func() {
+		select {
+		case <-sigs:
+		case <-done:
+			return
+		}
+
+		cancel(ErrInterrupted)
+
+		timeout := c.ShutdownTimeout
+		if timeout == 0 {
+			timeout = DefaultShutdownTimeout
+		}
+
+		var expired <-chan time.Time
+		if timeout > 0 {
+			expired = time.After(timeout)
+		}
+
+		for {
+			select {
+			case <-sigs:
+				if c.ExitOnSecondSignal {
+					_ = i.RestoreTty()
+					os.Exit(ExitInterrupted)
+				}
+			case <-expired:
+				_ = i.RestoreTty()
+				os.Exit(ExitInterrupted)
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return ctx, func() {
+		signal.Stop(sigs)
+		close(done)
+		cancel(nil)
 	}
 }
 
@@ -137,6 +251,19 @@ func (c *Command) execute(i *Input, o *Output) error {
 		return err
 	}
 
+	if err := c.init(); err != nil {
+		return err
+	}
+
+	path, idx, err := c.findPluginCall(i.Args)
+	if err != nil {
+		return err
+	}
+
+	if path != "" {
+		return c.runPlugin(i, o, path, i.Args[idx+1:])
+	}
+
 	command, args, err := c.findCommand(i.Args, &i.tokens)
 
 	if err != nil {
@@ -181,7 +308,7 @@ func (c *Command) execute(i *Input, o *Output) error {
 			i.tokens = array.Remove(i.tokens, incorrectName)
 		} else {
 			if len(alternatives) > 1 {
-				err = fmt.Errorf("command \"%s\" is ambiguous.\nDid you mean one of these?\n - %s", strings.Join(args, " "), strings.Join(alternatives, "\n - "))
+				err = Exit(ExitUsage, fmt.Errorf("command \"%s\" is ambiguous.\nDid you mean one of these?\n - %s", strings.Join(args, " "), strings.Join(alternatives, "\n - ")))
 			}
 
 			return err
@@ -201,8 +328,8 @@ func (c *Command) execute(i *Input, o *Output) error {
 
 	wantsHelp := !command.Hidden && command.hasFlag(i, "help") && (i.HasParameterFlag("--help", true) || i.HasParameterFlag("-h", true))
 	if wantsHelp {
-		command.printHelp(o)
-		return nil
+		// other formats are available through the --format flag of the help command
+		return command.describe(o, "txt")
 	}
 
 	wantsVersion := !command.Hidden && command.hasFlag(i, "version") && (i.HasParameterFlag("--version", true) || i.HasParameterFlag("-V", true))
@@ -211,17 +338,23 @@ func (c *Command) execute(i *Input, o *Output) error {
 		return nil
 	}
 
+	if err := c.loadConfig(i); err != nil {
+		return err
+	}
+
 	err = i.Bind(def)
 	if err != nil && !command.IgnoreValidationErrors {
-		return err
+		return Exit(ExitUsage, err)
 	}
 
+	command.warnDeprecations(i, o)
+
 	err = i.Validate()
 	if err != nil && !command.IgnoreValidationErrors {
 		if command.PromptForInput {
 			missingArgsErr, ok := err.(ErrMissingArguments)
 			if !ok {
-				return err
+				return Exit(ExitUsage, err)
 			}
 
 			err = command.doPromptForInput(i, o, missingArgsErr.MissingArguments())
@@ -229,10 +362,14 @@ func (c *Command) execute(i *Input, o *Output) error {
 				return err
 			}
 		} else {
-			return err
+			return Exit(ExitUsage, err)
 		}
 	}
 
+	for _, cmd := range command.lineage() {
+		cmd.populateOptions()
+	}
+
 	c.runningCommand = command
 
 	io := &IO{
@@ -243,10 +380,8 @@ func (c *Command) execute(i *Input, o *Output) error {
 		Args:       i.Args,
 	}
 
-	if command.RunE != nil {
-		err = command.RunE(io)
-	} else if command.Run != nil {
-		command.Run(io)
+	if command.RunE != nil || command.Run != nil {
+		err = command.run(io)
 	} else if command == c || command.HasSubcommands() {
 		command.printHelp(o)
 	} else {
@@ -258,6 +393,73 @@ func (c *Command) execute(i *Input, o *Output) error {
 	return err
 }
 
+// run invokes the handle of the command, surrounded by its lifecycle hooks. Persistent
+// pre-run hooks are invoked from the root down to the command, persistent post-run hooks
+// in reverse order. Post-run hooks are invoked even if the handle returns an error.
+func (c *Command) run(io *IO) error {
+	lineage := c.lineage()
+	preRunCount := 0
+
+	var err error
+	for _, cmd := range lineage {
+		if err = runHook(io, cmd.PersistentPreRun, cmd.PersistentPreRunE); err != nil {
+			break
+		}
+		preRunCount++
+	}
+
+	if err == nil {
+		err = runHook(io, c.PreRun, c.PreRunE)
+		if err == nil {
+			err = runHook(io, c.Run, c.RunE)
+			err = joinErrors(err, runHook(io, c.PostRun, c.PostRunE))
+		}
+	}
+
+	for i := preRunCount - 1; i >= 0; i-- {
+		cmd := lineage[i]
+		err = joinErrors(err, runHook(io, cmd.PersistentPostRun, cmd.PersistentPostRunE))
+	}
+
+	return err
+}
+
+func runHook(io *IO, hook CommandHandle, hookE CommandHandleE) error {
+	if hookE != nil {
+		return hookE(io)
+	}
+
+	if hook != nil {
+		hook(io)
+	}
+
+	return nil
+}
+
+func joinErrors(err error, other error) error {
+	if err == nil {
+		return other
+	}
+
+	if other == nil {
+		return err
+	}
+
+	return errors.Join(err, other)
+}
+
+// lineage returns the command and its ancestors, starting at the root.
+func (c *Command) lineage() []*Command {
+	lineage := make([]*Command, 0)
+	for cmd := c; cmd != nil; cmd = cmd.parent {
+		lineage = append(lineage, cmd)
+	}
+
+	slices.Reverse(lineage)
+
+	return lineage
+}
+
 func (c *Command) GetHelp() string {
 	version := c.version()
 
@@ -288,7 +490,7 @@ func (c *Command) VisibleSubcommandsCount() int {
 
 	count := 0
 	for _, cmd := range c.commands {
-		if !cmd.Hidden {
+		if cmd.IsVisible() {
 			count++
 		}
 	}
@@ -296,6 +498,46 @@ func (c *Command) VisibleSubcommandsCount() int {
 	return count
 }
 
+// IsVisible reports whether the command is listed in help output and shell completion.
+func (c *Command) IsVisible() bool {
+	return !c.Hidden && c.Deprecated == ""
+}
+
+func (c *Command) warnDeprecations(i *Input, o *Output) {
+	warnings := make([]string, 0)
+
+	if c.Deprecated != "" {
+		warnings = append(warnings, fmt.Sprintf("The \"%s\" command is deprecated: %s", c.FullName(), c.Deprecated))
+	}
+
+	if slices.Contains(c.DeprecatedAliases, c.calledAs) {
+		warnings = append(warnings, fmt.Sprintf("The \"%s\" alias is deprecated, use \"%s\" instead", c.calledAs, c.Name))
+	}
+
+	for _, flag := range i.DeprecatedFlags() {
+		warning := fmt.Sprintf("The \"--%s\" flag is deprecated", flag.GetName())
+		if replacement := FlagReplacement(flag); replacement != "" {
+			warning += fmt.Sprintf(", use \"--%s\" instead", replacement)
+		}
+
+		if d, ok := flag.(CanBeDeprecated); ok && d.GetDeprecated() != "" {
+			warning += ": " + d.GetDeprecated()
+		}
+
+		warnings = append(warnings, warning)
+	}
+
+	if len(warnings) == 0 {
+		return
+	}
+
+	if o.Stderr != nil {
+		o = o.Stderr
+	}
+
+	o.Warning(warnings...)
+}
+
 func (c *Command) version() string {
 	if c.LongVersion != "" {
 		return c.LongVersion
@@ -332,9 +574,13 @@ func (c *Command) AddCommand(command *Command) error {
 		return fmt.Errorf("command \"%s\" already exists", command.Name)
 	}
 
+	if err := command.buildOptions(); err != nil {
+		return err
+	}
+
 	c.commands[command.Name] = command
 
-	for _, alias := range command.Aliases {
+	for _, alias := range slices.Concat(command.Aliases, command.DeprecatedAliases) {
 		if _, exists := c.commands[alias]; exists {
 			return fmt.Errorf("command \"%s\" already exists", alias)
 		}
@@ -357,7 +603,11 @@ func (c *Command) Synopsis(short bool) string {
 	}
 
 	if c.synopsis[key] == "" {
-		d, _ := c.Definition()
+		d, err := c.Definition()
+		if err != nil {
+			return c.FullName()
+		}
+
 		c.synopsis[key] = strings.TrimSpace(fmt.Sprintf("%s %s", c.FullName(), d.Synopsis(short)))
 	}
 
@@ -460,8 +710,6 @@ func (c *Command) configureIO(i *Input, o *Output) {
 		} else if ok, err := i.Bool("no-ansi"); !ok && err == nil && i.FlagProvided("no-ansi") {
 			o.SetDecorated(false)
 		}
-	} else {
-		o.SetDecorated(HasColorSupport())
 	}
 
 	if c.hasFlag(i, "no-interaction") {
@@ -528,6 +776,37 @@ func (c *Command) configureIO(i *Input, o *Output) {
 	os.Setenv("SHELL_VERBOSITY", fmt.Sprint(shellVerbosity))
 }
 
+// SetIn sets the stream that the command reads input from. Defaults to os.Stdin.
+func (c *Command) SetIn(in io.Reader) {
+	c.inStream = in
+}
+
+// SetOut sets the stream that the command writes output to. Defaults to os.Stdout.
+func (c *Command) SetOut(out io.Writer) {
+	c.outStream = out
+}
+
+// SetErr sets the stream that the command writes errors to. Defaults to os.Stderr.
+func (c *Command) SetErr(err io.Writer) {
+	c.errStream = err
+}
+
+func (c *Command) outOrStdout() io.Writer {
+	if c.outStream != nil {
+		return c.outStream
+	}
+
+	return os.Stdout
+}
+
+func (c *Command) errOrStderr() io.Writer {
+	if c.errStream != nil {
+		return c.errStream
+	}
+
+	return os.Stderr
+}
+
 func (c *Command) SetParent(parent *Command) {
 	c.parent = parent
 }
@@ -536,13 +815,19 @@ func (c *Command) Parent() *Command {
 	return c.parent
 }
 
+// findCommand resolves the subcommand that is called by the arguments, and removes the
+// command names from the tokens. When a command does not exist, the path of command
+// names that was resolved, followed by the unknown name, is returned with the error.
 func (c *Command) findCommand(args []string, tokens *[]string) (*Command, []string, error) {
 	isOption := false
-	argc := len(args)
 	arguments := make([]string, 0)
 
 	current := c
-	definition, _ := current.Definition()
+	definition, err := current.Definition()
+	if err != nil {
+		return nil, nil, err
+	}
+
 	toRemove := make([]string, 0)
 
 	for idx, token := range args {
@@ -554,35 +839,7 @@ func (c *Command) findCommand(args []string, tokens *[]string) (*Command, []stri
 				break
 			}
 
-			// Has value, or is last token
-			if strings.Contains(token, "=") || idx+1 >= argc {
-				continue
-			}
-
-			// If it's a long option, consider that everything after "--" is the option name.
-			// Otherwise, use the last char (if it's a short option set, only the last one can take a value with space separator)
-			var name string
-			if strings.HasPrefix(token, "--") {
-				name = token[2:]
-			} else {
-				name = token[len(token)-1:]
-			}
-
-			flag, _ := definition.Flag(name)
-			if flag == nil {
-				// Try again with the shortcut
-				flag, _ = definition.FlagForShortcut(name)
-
-				if flag == nil {
-					continue
-				}
-			}
-
-			// If flag accepts a value, check if the next token is not an option value
-			if FlagAcceptsValue(flag) && !strings.HasPrefix(args[idx+1], "-") {
-				isOption = true
-			}
-
+			isOption = flagValueFollows(definition, args, idx)
 			continue
 		}
 
@@ -592,16 +849,24 @@ func (c *Command) findCommand(args []string, tokens *[]string) (*Command, []stri
 			continue
 		}
 
-		current.init()
+		if err := current.init(); err != nil {
+			return nil, nil, err
+		}
+
 		cmd := current.commands[token]
 		if cmd != nil {
 			current = cmd
-			definition, _ = current.Definition()
+			current.calledAs = token
+			definition, err = current.Definition()
+			if err != nil {
+				return nil, nil, err
+			}
+
 			toRemove = append(toRemove, token)
 		} else {
 			if len(definition.arguments) == 0 && current.HasSubcommands() {
 				alternatives := c.findAlternatives(token, array.SortedKeys(current.commands))
-				return nil, arguments[:idx+1], CommandNotFound(fmt.Sprintf("command \"%s\" does not exist", token), alternatives)
+				return nil, append(toRemove, token), CommandNotFound(fmt.Sprintf("command \"%s\" does not exist", token), alternatives)
 			}
 		}
 	}
@@ -615,6 +880,38 @@ func (c *Command) findCommand(args []string, tokens *[]string) (*Command, []stri
 	return current, nil, nil
 }
 
+// flagValueFollows reports whether the flag token at idx is followed by its value.
+func flagValueFollows(definition *InputDefinition, args []string, idx int) bool {
+	token := args[idx]
+
+	// Has value, or is last token
+	if strings.Contains(token, "=") || idx+1 >= len(args) {
+		return false
+	}
+
+	// If it's a long option, consider that everything after "--" is the option name.
+	// Otherwise, use the last char (if it's a short option set, only the last one can take a value with space separator)
+	var name string
+	if strings.HasPrefix(token, "--") {
+		name = token[2:]
+	} else {
+		name = token[len(token)-1:]
+	}
+
+	flag, _ := definition.Flag(name)
+	if flag == nil {
+		// Try again with the shortcut
+		flag, _ = definition.FlagForShortcut(name)
+
+		if flag == nil {
+			return false
+		}
+	}
+
+	// If flag accepts a value, check if the next token is not an option value
+	return FlagAcceptsValue(flag) && !strings.HasPrefix(args[idx+1], "-")
+}
+
 func (c *Command) defaultInputDefinition() (*InputDefinition, error) {
 	definition := &InputDefinition{}
 	flags := make([]Flag, 0, 6)
@@ -695,7 +992,7 @@ func (c *Command) defaultInputDefinition() (*InputDefinition, error) {
 
 func (c *Command) init() error {
 	if c.initialized {
-		return nil
+		return c.initErr
 	}
 
 	c.initialized = true
@@ -707,6 +1004,7 @@ func (c *Command) init() error {
 	for _, command := range c.Commands {
 		command.SetParent(c)
 		if err := c.AddCommand(command); err != nil {
+			c.initErr = err
 			return err
 		}
 	}
@@ -715,33 +1013,186 @@ func (c *Command) init() error {
 	return nil
 }
 
+// Definition returns the input definition of the command. A definition that cannot be
+// built, e.g. because of conflicting flags, is returned as an error and not cached.
 func (c *Command) Definition() (*InputDefinition, error) {
-	var err error
-	if c.definition == nil {
-		nativeDefinition, e := c.defaultInputDefinition()
-		if e != nil {
-			err = e
+	if c.definition != nil {
+		return c.definition, nil
+	}
+
+	for _, cmd := range c.lineage() {
+		if err := cmd.buildOptions(); err != nil {
+			return nil, err
 		}
+	}
 
-		c.definition = &InputDefinition{}
-		if e := c.definition.SetArguments(c.Arguments); e != nil && err != nil {
-			err = e
+	nativeDefinition, err := c.defaultInputDefinition()
+	if err != nil {
+		return nil, err
+	}
+
+	definition := &InputDefinition{}
+	if err := definition.SetArguments(c.Arguments); err != nil {
+		return nil, err
+	}
+
+	if err := definition.SetFlags(c.Flags); err != nil {
+		return nil, err
+	}
+
+	if err := definition.AddFlags(c.PersistentFlags); err != nil {
+		return nil, err
+	}
+
+	inheritedFlags, err := c.InheritedFlags()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := definition.AddFlags(inheritedFlags); err != nil {
+		return nil, err
+	}
+
+	definition.BindEnvVars(slices.Concat(c.Flags, c.PersistentFlags, inheritedFlags), c.Root().EnvPrefix)
+	c.bindConfigKeys(definition, inheritedFlags)
+
+	if err := definition.AddArguments(nativeDefinition.GetArguments()); err != nil {
+		return nil, err
+	}
+
+	// flags defined by the command take precedence over the native flags
+	nativeFlags := make([]Flag, 0)
+	for _, f := range nativeDefinition.GetFlags() {
+		if !definition.HasFlag(f.GetName()) && !definition.HasNegation(f.GetName()) {
+			nativeFlags = append(nativeFlags, f)
 		}
+	}
+
+	if err := definition.AddFlags(nativeFlags); err != nil {
+		return nil, err
+	}
+
+	if err := definition.SetFlagGroups(c.FlagGroups); err != nil {
+		return nil, err
+	}
+
+	c.definition = definition
+	return definition, nil
+}
+
+// InheritedFlags returns the persistent flags of the ancestors of the command. Flags
+// that are redefined by the command itself, or by an ancestor closer to the command,
+// are left out. An error is returned when the flags of an ancestor cannot be built.
+func (c *Command) InheritedFlags() ([]Flag, error) {
+	flags := make([]Flag, 0)
+	names := make(map[string]bool)
 
-		if e := c.definition.SetFlags(c.Flags); e != nil && err != nil {
-			err = e
+	for _, f := range c.Flags {
+		names[f.GetName()] = true
+	}
+
+	for _, f := range c.PersistentFlags {
+		names[f.GetName()] = true
+	}
+
+	for parent := c.parent; parent != nil; parent = parent.parent {
+		if err := parent.buildOptions(); err != nil {
+			return nil, err
 		}
 
-		if e := c.definition.AddArguments(nativeDefinition.GetArguments()); e != nil && err != nil {
-			err = e
+		for _, f := range parent.PersistentFlags {
+			if names[f.GetName()] {
+				continue
+			}
+
+			names[f.GetName()] = true
+			flags = append(flags, f)
 		}
+	}
+
+	return flags, nil
+}
+
+// bindConfigKeys binds the flags of the command to the configuration keys they are
+// read from, e.g. "deploy.region". Inherited flags can also be configured under any
+// command up to the one defining them.
+func (c *Command) bindConfigKeys(definition *InputDefinition, inheritedFlags []Flag) {
+	path := c.configPath()
+
+	for _, f := range slices.Concat(c.Flags, c.PersistentFlags) {
+		definition.BindConfigKeys(f.GetName(), []string{configKey(path, f.GetName())})
+	}
+
+	for _, f := range inheritedFlags {
+		keys := []string{configKey(path, f.GetName())}
+		for parent := c.parent; parent != nil; parent = parent.parent {
+			keys = append(keys, configKey(parent.configPath(), f.GetName()))
+			if slices.Contains(parent.PersistentFlags, f) {
+				break
+			}
+		}
+
+		definition.BindConfigKeys(f.GetName(), keys)
+	}
+}
+
+func (c *Command) configPath() string {
+	lineage := c.lineage()
+	names := make([]string, 0, len(lineage))
+	for _, cmd := range lineage[1:] {
+		names = append(names, cmd.Name)
+	}
+
+	return strings.Join(names, ".")
+}
+
+func configKey(path string, name string) string {
+	if path == "" {
+		return name
+	}
+
+	return path + "." + name
+}
+
+func (c *Command) initConfigFlag() {
+	for _, f := range slices.Concat(c.Flags, c.PersistentFlags) {
+		if f.GetName() == "config" {
+			return
+		}
+	}
+
+	c.PersistentFlags = append(c.PersistentFlags, &StringFlag{
+		Name:        "config",
+		Description: "Path to a configuration file",
+	})
+	c.definition = nil
+}
+
+// loadConfig loads the configuration files of the command, followed by the file given
+// with the --config flag, if any.
+func (c *Command) loadConfig(i *Input) error {
+	if c.ConfigName == "" {
+		return nil
+	}
+
+	config, err := LoadConfig(ConfigFiles(c.ConfigName)...)
+	if err != nil {
+		return err
+	}
+
+	path, _ := i.ParameterFlag("--config", "", true).(string)
+	if path == "" && c.EnvPrefix != "" {
+		path = os.Getenv(envVarName(c.EnvPrefix, "config"))
+	}
 
-		if e := c.definition.AddFlags(nativeDefinition.GetFlags()); e != nil && err != nil {
-			err = e
+	if path != "" {
+		if err := config.Load(path); err != nil {
+			return err
 		}
 	}
 
-	return c.definition, err
+	i.SetConfig(config)
+	return nil
 }
 
 func (c *Command) printHelp(output *Output) {
@@ -754,6 +1205,22 @@ func (c *Command) printHelp(output *Output) {
 	d.DescribeCommand(c, &DescriptorOptions{})
 }
 
+// describe prints the help of the command in the given format, see DescriptorFormats.
+func (c *Command) describe(output *Output, format string) error {
+	if format == "txt" || format == "" {
+		c.printHelp(output)
+		return nil
+	}
+
+	d, err := NewDescriptor(format, output)
+	if err != nil {
+		return err
+	}
+
+	d.DescribeCommand(c, &DescriptorOptions{})
+	return nil
+}
+
 func (c *Command) doPromptForInput(i *Input, o *Output, missingArgs []string) error {
 	for _, arg := range missingArgs {
 		a, err := i.definition.Argument(arg)
@@ -846,6 +1313,24 @@ func (c *Command) promptArgument(i *Input, o *Output, arg Arg) error {
 		i.Args = append(i.Args, answers...)
 
 		return nil
+	case *IntArg, *UintArg, *FloatArg, *DurationArg, *TimeArg:
+		prompt := NewTextPrompt(i, o, fmt.Sprintf("What is %s?", q), "")
+		prompt.Required = true
+		prompt.Validator = func(value string) string {
+			ArgSetValue(a, value)
+			if err := ValidateArg(a); err != nil {
+				return err.Error()
+			}
+			return ""
+		}
+
+		answer, err := prompt.Render()
+		if err != nil {
+			return err
+		}
+
+		i.Args = append(i.Args, answer)
+		return i.SetArgument(name, answer)
 	default:
 		return errors.New("unsupported argument type")
 	}
@@ -991,9 +1476,19 @@ func (c *Command) Subcommands() map[string]*Command {
 }
 
 func (c *Command) Flag(name string) (Flag, error) {
-	return c.definition.Flag(name)
+	definition, err := c.Definition()
+	if err != nil {
+		return nil, err
+	}
+
+	return definition.Flag(name)
 }
 
 func (c *Command) Arg(name string) (Arg, error) {
-	return c.definition.Argument(name)
+	definition, err := c.Definition()
+	if err != nil {
+		return nil, err
+	}
+
+	return definition.Argument(name)
 }
@@ -0,0 +1,83 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestPersistentFlagConflictsAreReported(t *testing.T) {
+	c := &Command{
+		Name: "app",
+		Flags: []Flag{
+			&StringFlag{Name: "region"},
+		},
+		PersistentFlags: []Flag{
+			&StringFlag{Name: "region"},
+		},
+	}
+
+	if _, err := c.Definition(); err == nil {
+		t.Errorf("expected a conflict between the flag and the persistent flag \"region\"")
+	}
+}
+
+func TestFlagsTakePrecedenceOverNativeFlags(t *testing.T) {
+	c := &Command{
+		Name: "app",
+		Flags: []Flag{
+			&StringFlag{Name: "version", Description: "The version to deploy"},
+		},
+	}
+
+	d, err := c.Definition()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	f, _ := d.Flag("version")
+	if _, ok := f.(*StringFlag); !ok {
+		t.Errorf("expected the \"version\" flag of the command, got %T", f)
+	}
+
+	if !d.HasFlag("quiet") {
+		t.Errorf("expected the native flags after \"version\" to be defined")
+	}
+}
+
+func TestHelpFlagDoesNotReadCommandFlags(t *testing.T) {
+	var out bytes.Buffer
+	app := &Command{
+		Name: "app",
+		Commands: []*Command{
+			{
+				Name:        "export",
+				Description: "Export the data",
+				Flags: []Flag{
+					&StringFlag{Name: "format", Options: []string{"csv", "tsv"}},
+				},
+				Run: func(io *IO) {},
+			},
+		},
+	}
+	app.SetOut(&out)
+	app.SetErr(&out)
+
+	if _, err := app.Execute("export", "--format=csv", "--help"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(out.String(), "Export the data") {
+		t.Errorf("expected the text help of the command, got %q", out.String())
+	}
+
+	out.Reset()
+	if _, err := app.Execute("help", "--format=json", "export"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !json.Valid(out.Bytes()) {
+		t.Errorf("expected the help command to describe the command as JSON, got %q", out.String())
+	}
+}
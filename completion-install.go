@@ -0,0 +1,227 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var installableShells = []string{"bash", "fish", "zsh"}
+
+// completionTarget describes where the completion script of a shell is installed, and
+// what has to be added to the rc file of the shell to load it.
+type completionTarget struct {
+	shell   string
+	path    string
+	rcFile  string
+	rcLines []string
+	notes   []string
+}
+
+func completionInstallCmd() *Command {
+	return &Command{
+		Name:        "install",
+		Description: "Install the autocompletion script for the current shell",
+		Help: `Install the autocompletion script for the current shell, which is detected from
+the <accent>$SHELL</accent> environment variable, or given by the <accent>--shell</accent> flag.
+
+The script is written to the conventional per-user location of the shell:
+
+  bash: $XDG_DATA_HOME/bash-completion/completions
+  fish: $XDG_CONFIG_HOME/fish/completions
+  zsh:  ~/.zfunc
+
+Use <accent>--dry-run</accent> to see what would be done without writing any files.`,
+		Flags: []Flag{
+			newShellFlag(),
+			newDryRunFlag(),
+		},
+		RunE: func(io *IO) error {
+			return io.Command.Root().installCompletion(io, io.String("shell"), io.Bool("dry-run"))
+		},
+	}
+}
+
+func completionUninstallCmd() *Command {
+	return &Command{
+		Name:        "uninstall",
+		Description: "Uninstall the autocompletion script for the current shell",
+		Help: `Remove the autocompletion script that was written by the <accent>completion install</accent> command.
+
+Use <accent>--dry-run</accent> to see what would be done without removing any files.`,
+		Flags: []Flag{
+			newShellFlag(),
+			newDryRunFlag(),
+		},
+		RunE: func(io *IO) error {
+			return io.Command.Root().uninstallCompletion(io, io.String("shell"), io.Bool("dry-run"))
+		},
+	}
+}
+
+func newShellFlag() *StringFlag {
+	return &StringFlag{
+		Name:        "shell",
+		Description: "The shell to install the script for, detected from $SHELL by default",
+		Options:     installableShells,
+	}
+}
+
+func newDryRunFlag() *BoolFlag {
+	return &BoolFlag{
+		Name:        "dry-run",
+		Description: "Print what would be done without changing any files",
+	}
+}
+
+func (c *Command) installCompletion(io *IO, shell string, dryRun bool) error {
+	target, err := newCompletionTarget(shell, c.Name)
+	if err != nil {
+		return err
+	}
+
+	script := new(bytes.Buffer)
+	if err := c.genCompletion(target.shell, script); err != nil {
+		return err
+	}
+
+	if dryRun {
+		io.Writelnf("Would write the %s completion script to <accent>%s</accent>", target.shell, target.path)
+	} else {
+		if err := os.MkdirAll(filepath.Dir(target.path), 0755); err != nil {
+			return err
+		}
+
+		if err := os.WriteFile(target.path, script.Bytes(), 0644); err != nil {
+			return err
+		}
+
+		io.Writelnf("Installed the %s completion script to <accent>%s</accent>", target.shell, target.path)
+	}
+
+	if len(target.rcLines) > 0 {
+		io.NewLine(1)
+		io.Writelnf("Add the following to <accent>%s</accent>, if not present already:", target.rcFile)
+		io.NewLine(1)
+		for _, line := range target.rcLines {
+			io.Writeln("  " + line)
+		}
+	}
+
+	io.NewLine(1)
+	io.Writelns(target.notes)
+	io.Writeln("You will need to start a new shell for this setup to take effect.")
+
+	return nil
+}
+
+func (c *Command) uninstallCompletion(io *IO, shell string, dryRun bool) error {
+	target, err := newCompletionTarget(shell, c.Name)
+	if err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(target.path); errors.Is(err, os.ErrNotExist) {
+		io.Writelnf("No %s completion script is installed at <accent>%s</accent>", target.shell, target.path)
+		return nil
+	}
+
+	if dryRun {
+		io.Writelnf("Would remove the %s completion script at <accent>%s</accent>", target.shell, target.path)
+	} else {
+		if err := os.Remove(target.path); err != nil {
+			return err
+		}
+
+		io.Writelnf("Removed the %s completion script from <accent>%s</accent>", target.shell, target.path)
+	}
+
+	if len(target.rcLines) > 0 {
+		io.NewLine(1)
+		io.Writelnf("You can remove the following from <accent>%s</accent>, if nothing else depends on it:", target.rcFile)
+		io.NewLine(1)
+		for _, line := range target.rcLines {
+			io.Writeln("  " + line)
+		}
+	}
+
+	return nil
+}
+
+func (c *Command) genCompletion(shell string, w io.Writer) error {
+	switch shell {
+	case "bash":
+		return c.GenBashCompletion(w)
+	case "fish":
+		return c.GenFishCompletion(w)
+	case "zsh":
+		return c.GenZshCompletion(w)
+	default:
+		return fmt.Errorf("unsupported shell \"%s\". Expected one of: %s", shell, strings.Join(installableShells, ", "))
+	}
+}
+
+// newCompletionTarget returns the install location of the completion script of the
+// given shell. An empty shell is detected from $SHELL.
+func newCompletionTarget(shell string, name string) (*completionTarget, error) {
+	if shell == "" {
+		shell = filepath.Base(os.Getenv("SHELL"))
+		if shell == "." || shell == "/" {
+			return nil, errors.New("unable to detect the shell from $SHELL, please specify it with --shell")
+		}
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, err
+	}
+
+	switch shell {
+	case "bash":
+		dir := strings.Split(os.Getenv("BASH_COMPLETION_USER_DIR"), ":")[0]
+		if dir == "" {
+			dir = filepath.Join(xdgDir("XDG_DATA_HOME", filepath.Join(home, ".local", "share")), "bash-completion")
+		}
+
+		return &completionTarget{
+			shell: shell,
+			path:  filepath.Join(dir, "completions", name),
+			notes: []string{"The script depends on the 'bash-completion' package, which loads it automatically."},
+		}, nil
+	case "fish":
+		return &completionTarget{
+			shell: shell,
+			path:  filepath.Join(xdgDir("XDG_CONFIG_HOME", filepath.Join(home, ".config")), "fish", "completions", name+".fish"),
+		}, nil
+	case "zsh":
+		dir := filepath.Join(home, ".zfunc")
+		rcDir := os.Getenv("ZDOTDIR")
+		if rcDir == "" {
+			rcDir = home
+		}
+
+		return &completionTarget{
+			shell:  shell,
+			path:   filepath.Join(dir, "_"+name),
+			rcFile: filepath.Join(rcDir, ".zshrc"),
+			rcLines: []string{
+				fmt.Sprintf("fpath=(%s $fpath)", dir),
+				"autoload -U compinit && compinit",
+			},
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported shell \"%s\". Expected one of: %s", shell, strings.Join(installableShells, ", "))
+	}
+}
+
+func xdgDir(env string, fallback string) string {
+	if dir := os.Getenv(env); dir != "" {
+		return dir
+	}
+
+	return fallback
+}
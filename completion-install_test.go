@@ -0,0 +1,140 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCompletionTargets(t *testing.T) {
+	home := t.TempDir()
+
+	tests := []struct {
+		name   string
+		shell  string
+		env    map[string]string
+		path   string
+		rcFile string
+	}{
+		{
+			name: "shell detected from $SHELL",
+			env:  map[string]string{"SHELL": "/usr/bin/fish"},
+			path: filepath.Join(home, ".config", "fish", "completions", "app.fish"),
+		},
+		{
+			name:  "bash",
+			shell: "bash",
+			path:  filepath.Join(home, ".local", "share", "bash-completion", "completions", "app"),
+		},
+		{
+			name:  "bash in $XDG_DATA_HOME",
+			shell: "bash",
+			env:   map[string]string{"XDG_DATA_HOME": "/data"},
+			path:  filepath.Join("/data", "bash-completion", "completions", "app"),
+		},
+		{
+			name:  "bash in $BASH_COMPLETION_USER_DIR",
+			shell: "bash",
+			env:   map[string]string{"XDG_DATA_HOME": "/data", "BASH_COMPLETION_USER_DIR": "/first:/second"},
+			path:  filepath.Join("/first", "completions", "app"),
+		},
+		{
+			name:  "fish in $XDG_CONFIG_HOME",
+			shell: "fish",
+			env:   map[string]string{"XDG_CONFIG_HOME": "/config"},
+			path:  filepath.Join("/config", "fish", "completions", "app.fish"),
+		},
+		{
+			name:   "zsh",
+			shell:  "zsh",
+			path:   filepath.Join(home, ".zfunc", "_app"),
+			rcFile: filepath.Join(home, ".zshrc"),
+		},
+		{
+			name:   "zsh with $ZDOTDIR",
+			shell:  "zsh",
+			env:    map[string]string{"ZDOTDIR": "/zsh"},
+			path:   filepath.Join(home, ".zfunc", "_app"),
+			rcFile: filepath.Join("/zsh", ".zshrc"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Setenv("HOME", home)
+			for _, name := range []string{"SHELL", "XDG_DATA_HOME", "XDG_CONFIG_HOME", "BASH_COMPLETION_USER_DIR", "ZDOTDIR"} {
+				t.Setenv(name, test.env[name])
+			}
+
+			target, err := newCompletionTarget(test.shell, "app")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if target.path != test.path {
+				t.Errorf("expected path %q, got %q", test.path, target.path)
+			}
+
+			if target.rcFile != test.rcFile {
+				t.Errorf("expected rc file %q, got %q", test.rcFile, target.rcFile)
+			}
+		})
+	}
+}
+
+func TestCompletionTargetErrors(t *testing.T) {
+	t.Setenv("SHELL", "")
+	if _, err := newCompletionTarget("", "app"); err == nil {
+		t.Errorf("expected an error when the shell cannot be detected")
+	}
+
+	if _, err := newCompletionTarget("tcsh", "app"); err == nil {
+		t.Errorf("expected an error for an unsupported shell")
+	}
+}
+
+func TestCompletionInstallRoundTrip(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_DATA_HOME", "")
+	t.Setenv("BASH_COMPLETION_USER_DIR", "")
+
+	path := filepath.Join(home, ".local", "share", "bash-completion", "completions", "app")
+	run := func(args ...string) {
+		t.Helper()
+
+		app := &Command{
+			Name: "app",
+			Commands: []*Command{
+				{Name: "deploy", Run: func(io *IO) {}},
+			},
+		}
+		app.SetOut(&bytes.Buffer{})
+		app.SetErr(&bytes.Buffer{})
+
+		if _, err := app.Execute(append([]string{"completion"}, args...)...); err != nil {
+			t.Fatalf("unexpected error for %v: %v", args, err)
+		}
+	}
+
+	run("install", "--shell=bash", "--dry-run")
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("expected a dry run not to write the script, got %v", err)
+	}
+
+	run("install", "--shell=bash")
+	if script, err := os.ReadFile(path); err != nil || !bytes.Contains(script, []byte("bash completion")) {
+		t.Fatalf("expected the bash completion script to be installed, got %v", err)
+	}
+
+	run("uninstall", "--shell=bash", "--dry-run")
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("expected a dry run not to remove the script, got %v", err)
+	}
+
+	run("uninstall", "--shell=bash")
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("expected the script to be removed, got %v", err)
+	}
+}
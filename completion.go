@@ -71,6 +71,15 @@ const (
 	ShellCompDirectiveDefault ShellCompDirective = 0
 )
 
+// CompletionFunc returns the completions for the value being completed, and a directive
+// for the shell. A completion can have a description, separated by a tab, e.g.
+// "main\tThe default branch". The args are the arguments given so far.
+type CompletionFunc func(io *IO, args []string, toComplete string) ([]string, ShellCompDirective)
+
+type HasCompleteFunc interface {
+	GetCompleteFunc() CompletionFunc
+}
+
 func (d ShellCompDirective) string() string {
 	var directives []string
 	if d&ShellCompDirectiveError != 0 {
@@ -101,7 +110,11 @@ func (d ShellCompDirective) string() string {
 	return strings.Join(directives, ", ")
 }
 
-func (c *Command) initCompleteCmd(args []string) {
+func (c *Command) initCompleteCmd() {
+	if _, exists := c.commands[ShellCompRequestCmd]; exists {
+		return
+	}
+
 	completeCmd := &Command{
 		Name:   ShellCompRequestCmd,
 		Hidden: true,
@@ -117,7 +130,7 @@ func (c *Command) initCompleteCmd(args []string) {
 			"to request completion choices for the specified command-line.", ShellCompRequestCmd),
 		Run: func(io *IO) {
 			cmd := io.Command
-			_, completions, directive, err := cmd.getCompletions(io.Input, args)
+			_, completions, directive, err := cmd.getCompletions(io.Input, io.Output, io.Input.Args)
 			if err != nil {
 				CompErrorln(io.Output.Formatter().RemoveDecoration(StripEscapeSequences(err.Error())))
 			}
@@ -137,7 +150,7 @@ func (c *Command) initCompleteCmd(args []string) {
 	c.AddCommand(completeCmd)
 }
 
-func (c *Command) getCompletions(i *Input, args []string) (finalCmd *Command, completions []string, directive ShellCompDirective, err error) {
+func (c *Command) getCompletions(i *Input, o *Output, args []string) (finalCmd *Command, completions []string, directive ShellCompDirective, err error) {
 	root := c.Root()
 	root.init()
 
@@ -174,6 +187,7 @@ func (c *Command) getCompletions(i *Input, args []string) (finalCmd *Command, co
 		return
 	}
 
+	i.tokens = tokens
 	i.Bind(definition)
 	inspectable := make([]string, len(tokens))
 	copy(inspectable, tokens)
@@ -188,6 +202,26 @@ func (c *Command) getCompletions(i *Input, args []string) (finalCmd *Command, co
 
 	directive = ShellCompDirectiveDefault
 
+	given := func(name string) bool {
+		f, _ := definition.Flag(name)
+		return f != nil && inspection.FlagIsGiven(f)
+	}
+
+	io := &IO{
+		Command:    finalCmd,
+		Input:      i,
+		Output:     o,
+		definition: definition,
+		Args:       inspection.Args,
+	}
+
+	if !flagCompletion && len(tokens) > 0 {
+		if flag := flagAwaitingValue(definition, tokens[len(tokens)-1]); flag != nil {
+			completions, directive = completeFlagValue(io, flag, inspection.Args, toComplete)
+			return
+		}
+	}
+
 	if flagCompletion {
 		includeShort := strings.HasPrefix(toComplete, "-") && !strings.HasPrefix(toComplete, "--")
 		isShort := includeShort
@@ -196,28 +230,19 @@ func (c *Command) getCompletions(i *Input, args []string) (finalCmd *Command, co
 			toComplete = strings.TrimPrefix(toComplete, "-")
 		}
 
-		hasValue := false
-		if strings.HasSuffix(toComplete, "=") {
-			hasValue = true
-			toComplete = strings.TrimSuffix(toComplete, "=")
-		}
+		name, value, hasValue := strings.Cut(toComplete, "=")
+		toComplete = name
 
 		flag, _ := definition.Flag(toComplete)
 
 		if flag != nil {
 			if (hasValue || len(toComplete) == 1) && (FlagRequiresValue(flag) || FlagValueIsOptional(flag)) {
-				opts, ok := flag.(HasOptions)
-				if !ok {
-					return
-				}
-
-				completions = opts.Opts()
-				directive = ShellCompDirectiveDefault
+				completions, directive = completeFlagValue(io, flag, inspection.Args, value)
 			} else if isShort {
 				completions = make([]string, 0)
 				directive = ShellCompDirectiveNoSpace
 				for _, f := range definition.flags {
-					if inspection.FlagIsGiven(f) {
+					if inspection.FlagIsGiven(f) || FlagIsDeprecated(f) || definition.FlagIsExcluded(f.GetName(), given) {
 						continue
 					}
 
@@ -243,7 +268,7 @@ func (c *Command) getCompletions(i *Input, args []string) (finalCmd *Command, co
 			completions = make([]string, 0)
 			directive = ShellCompDirectiveNoSpace
 			for _, f := range definition.flags {
-				if inspection.FlagIsGiven(f) {
+				if inspection.FlagIsGiven(f) || FlagIsDeprecated(f) || definition.FlagIsExcluded(f.GetName(), given) {
 					continue
 				}
 
@@ -263,7 +288,7 @@ func (c *Command) getCompletions(i *Input, args []string) (finalCmd *Command, co
 		completions = make([]string, 0)
 
 		for _, f := range definition.flags {
-			if inspection.FlagIsGiven(f) {
+			if inspection.FlagIsGiven(f) || FlagIsDeprecated(f) || definition.FlagIsExcluded(f.GetName(), given) {
 				continue
 			}
 
@@ -301,13 +326,18 @@ func (c *Command) getCompletions(i *Input, args []string) (finalCmd *Command, co
 			}
 
 			switch arg.(type) {
-			case *StringArg:
+			case *StringArg, *IntArg, *UintArg, *FloatArg, *DurationArg, *TimeArg:
 				hasToken := j < len(inspection.Args)
 				j++
 				if hasToken {
 					continue
 				}
 
+				if fn := argCompleteFunc(finalCmd, arg); fn != nil {
+					completions, directive = fn(io, inspection.Args, toComplete)
+					return
+				}
+
 				hasOpts := len(arg.Opts()) > 0
 				if !hasOpts {
 					if arg.IsRequired() {
@@ -321,6 +351,11 @@ func (c *Command) getCompletions(i *Input, args []string) (finalCmd *Command, co
 				completions = append(completions, arg.Opts()...)
 				return
 			case *ArrayArg:
+				if fn := argCompleteFunc(finalCmd, arg); fn != nil {
+					completions, directive = fn(io, inspection.Args, toComplete)
+					return
+				}
+
 				if opts := arg.Opts(); len(opts) > 0 {
 					availableOptions := make([]string, 0, len(opts))
 					for _, opt := range opts {
@@ -348,10 +383,16 @@ func (c *Command) getCompletions(i *Input, args []string) (finalCmd *Command, co
 		completions = make([]string, 0)
 
 		for _, cmd := range finalCmd.Subcommands() {
-			if !cmd.Hidden {
+			if cmd.IsVisible() {
 				completions = append(completions, fmt.Sprintf("%s\t%s", cmd.Name, cmd.Description))
 			}
 		}
+
+		for _, plugin := range finalCmd.rootPlugins() {
+			completions = append(completions, fmt.Sprintf("%s\t%s", plugin.Name, plugin.Description))
+		}
+	} else if finalCmd.CompleteFunc != nil && len(finalCmd.Arguments) == 0 {
+		completions, directive = finalCmd.CompleteFunc(io, inspection.Args, toComplete)
 	} else {
 		if optionalArg {
 			directive = ShellCompDirectiveDefault
@@ -363,7 +404,58 @@ func (c *Command) getCompletions(i *Input, args []string) (finalCmd *Command, co
 	return
 }
 
-func (c *Command) InitDefaultCompletionCmd(out *os.File) {
+// argCompleteFunc returns the completion function of the argument. Arguments without a
+// function or options are completed by the completion function of the command.
+func argCompleteFunc(command *Command, arg Arg) CompletionFunc {
+	if a, ok := arg.(HasCompleteFunc); ok && a.GetCompleteFunc() != nil {
+		return a.GetCompleteFunc()
+	}
+
+	if len(arg.Opts()) == 0 {
+		return command.CompleteFunc
+	}
+
+	return nil
+}
+
+func completeFlagValue(io *IO, flag Flag, args []string, toComplete string) ([]string, ShellCompDirective) {
+	if f, ok := flag.(HasCompleteFunc); ok && f.GetCompleteFunc() != nil {
+		return f.GetCompleteFunc()(io, args, toComplete)
+	}
+
+	if opts, ok := flag.(HasOptions); ok {
+		return opts.Opts(), ShellCompDirectiveDefault
+	}
+
+	if opts, ok := flagValue(flag).(HasOptions); ok {
+		return opts.Opts(), ShellCompDirectiveDefault
+	}
+
+	return nil, ShellCompDirectiveDefault
+}
+
+// flagAwaitingValue returns the flag of the token, if the flag requires a value that is
+// given in the next token, e.g. "--region eu".
+func flagAwaitingValue(definition *InputDefinition, token string) Flag {
+	if token == "--" || !strings.HasPrefix(token, "-") || strings.Contains(token, "=") {
+		return nil
+	}
+
+	var flag Flag
+	if strings.HasPrefix(token, "--") {
+		flag, _ = definition.Flag(token[2:])
+	} else {
+		flag, _ = definition.FlagForShortcut(token[len(token)-1:])
+	}
+
+	if flag == nil || !FlagRequiresValue(flag) {
+		return nil
+	}
+
+	return flag
+}
+
+func (c *Command) InitDefaultCompletionCmd() {
 	if !c.HasSubcommands() {
 		return
 	}
@@ -410,14 +502,97 @@ To load completions for every new session, execute once:
 
 	%[1]s completion zsh > $(brew --prefix)/share/zsh/site-functions/_%[1]s
 
+Alternatively, install the script to the conventional location with:
+
+	%[1]s completion install --shell=zsh
+
+You will need to start a new shell for this setup to take effect.
+`, c.Root().Name),
+		RunE: func(io *IO) error {
+			return io.Command.Root().GenZshCompletion(io.Output.Stream)
+		},
+	}
+
+	bash := &Command{
+		Name:        "bash",
+		Description: fmt.Sprintf(shortDesc, "bash"),
+		Help: fmt.Sprintf(`Generate the autocompletion script for the bash shell.
+
+This script depends on the 'bash-completion' package.
+If it is not installed already, you can install it via your OS's package manager.
+
+To load completions in your current shell session:
+
+	source <(%[1]s completion bash)
+
+To load completions for every new session, execute once:
+
+#### Linux:
+
+	%[1]s completion bash > /etc/bash_completion.d/%[1]s
+
+#### macOS:
+
+	%[1]s completion bash > $(brew --prefix)/etc/bash_completion.d/%[1]s
+
+Alternatively, install the script to the conventional location with:
+
+	%[1]s completion install --shell=bash
+
 You will need to start a new shell for this setup to take effect.
 `, c.Root().Name),
 		RunE: func(io *IO) error {
-			return io.Command.Root().GenZshCompletion(out)
+			return io.Command.Root().GenBashCompletion(io.Output.Stream)
+		},
+	}
+
+	fish := &Command{
+		Name:        "fish",
+		Description: fmt.Sprintf(shortDesc, "fish"),
+		Help: fmt.Sprintf(`Generate the autocompletion script for the fish shell.
+
+To load completions in your current shell session:
+
+	%[1]s completion fish | source
+
+To load completions for every new session, execute once:
+
+	%[1]s completion fish > ~/.config/fish/completions/%[1]s.fish
+
+Alternatively, install the script to the conventional location with:
+
+	%[1]s completion install --shell=fish
+
+You will need to start a new shell for this setup to take effect.
+`, c.Root().Name),
+		RunE: func(io *IO) error {
+			return io.Command.Root().GenFishCompletion(io.Output.Stream)
+		},
+	}
+
+	powershell := &Command{
+		Name:        "powershell",
+		Description: fmt.Sprintf(shortDesc, "powershell"),
+		Help: fmt.Sprintf(`Generate the autocompletion script for powershell.
+
+To load completions in your current shell session:
+
+	%[1]s completion powershell | Out-String | Invoke-Expression
+
+To load completions for every new session, add the output of the above command
+to your powershell profile.
+`, c.Root().Name),
+		RunE: func(io *IO) error {
+			return io.Command.Root().GenPowerShellCompletion(io.Output.Stream)
 		},
 	}
 
+	completionCmd.AddCommand(bash)
 	completionCmd.AddCommand(zsh)
+	completionCmd.AddCommand(fish)
+	completionCmd.AddCommand(powershell)
+	completionCmd.AddCommand(completionInstallCmd())
+	completionCmd.AddCommand(completionUninstallCmd())
 }
 
 func CompError(msg string) {
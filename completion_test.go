@@ -0,0 +1,53 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestCompletionCommandsCanRunRepeatedly(t *testing.T) {
+	app := &Command{
+		Name: "app",
+		Commands: []*Command{
+			{Name: "deploy", Flags: []Flag{&StringFlag{Name: "region"}}, Run: func(io *IO) {}},
+			{Name: "status", Flags: []Flag{&BoolFlag{Name: "verbose"}}, Run: func(io *IO) {}},
+		},
+	}
+
+	for range 2 {
+		var out bytes.Buffer
+		app.SetOut(&out)
+		app.SetErr(&bytes.Buffer{})
+
+		if _, err := app.Execute("completion", "bash"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if !strings.Contains(out.String(), "bash completion") {
+			t.Errorf("expected the bash completion script, got %d bytes", out.Len())
+		}
+	}
+
+	tests := []struct {
+		args     []string
+		expected string
+	}{
+		{[]string{"deploy", "--re"}, "--region"},
+		{[]string{"status", "--ve"}, "--verbose"},
+	}
+
+	for _, test := range tests {
+		var out bytes.Buffer
+		app.SetOut(&out)
+		app.SetErr(&bytes.Buffer{})
+
+		if _, err := app.Execute(append([]string{ShellCompRequestCmd}, test.args...)...); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if !strings.HasPrefix(out.String(), test.expected) {
+			t.Errorf("expected %q to be completed first for %v, got %q", test.expected, test.args, out.String())
+		}
+	}
+}
@@ -0,0 +1,394 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/michielnijenhuis/cli/helper/array"
+)
+
+// Config holds configuration values loaded from one or more files. Nested values are
+// flattened into dot separated keys, e.g. "deploy.region".
+type Config struct {
+	values map[string]any
+}
+
+var configExtensions = []string{".json", ".toml", ".ini"}
+
+func NewConfig() *Config {
+	return &Config{
+		values: make(map[string]any),
+	}
+}
+
+// LoadConfig loads the given files in order, values of later files take precedence.
+// Files that do not exist are skipped.
+func LoadConfig(paths ...string) (*Config, error) {
+	c := NewConfig()
+
+	for _, path := range paths {
+		err := c.Load(path)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+	}
+
+	return c, nil
+}
+
+// Load loads the file at the given path and merges it into the config. The format is
+// derived from the extension: JSON for ".json", an INI/TOML subset for everything else.
+func (c *Config) Load(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var values map[string]any
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		// numbers are kept as json.Number, so large integers do not lose precision
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.UseNumber()
+		err = decoder.Decode(&values)
+	} else {
+		values, err = parseIniConfig(string(data))
+	}
+
+	if err != nil {
+		return fmt.Errorf("invalid config file \"%s\": %s", path, err)
+	}
+
+	c.Merge(values)
+	return nil
+}
+
+// Merge merges the given values into the config, overriding existing keys.
+func (c *Config) Merge(values map[string]any) {
+	if c.values == nil {
+		c.values = make(map[string]any)
+	}
+
+	flattenConfig(c.values, "", values)
+}
+
+func flattenConfig(dst map[string]any, prefix string, values map[string]any) {
+	for key, value := range values {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+
+		if nested, ok := value.(map[string]any); ok {
+			flattenConfig(dst, key, nested)
+			continue
+		}
+
+		dst[key] = value
+	}
+}
+
+func (c *Config) Keys() []string {
+	return array.SortedKeys(c.values)
+}
+
+func (c *Config) Has(key string) bool {
+	_, ok := c.values[key]
+	return ok
+}
+
+func (c *Config) Get(key string) (any, bool) {
+	value, ok := c.values[key]
+	return value, ok
+}
+
+func (c *Config) String(key string) string {
+	value, ok := c.values[key]
+	if !ok {
+		return ""
+	}
+
+	return formatConfigValue(value)
+}
+
+func (c *Config) Bool(key string) bool {
+	switch value := c.values[key].(type) {
+	case bool:
+		return value
+	case string:
+		b, _ := strconv.ParseBool(value)
+		return b
+	default:
+		return false
+	}
+}
+
+func (c *Config) Int(key string) int {
+	switch value := c.values[key].(type) {
+	case json.Number:
+		if i, err := value.Int64(); err == nil {
+			return int(i)
+		}
+	case string:
+		if i, err := strconv.Atoi(value); err == nil {
+			return i
+		}
+	}
+
+	return int(c.Float(key))
+}
+
+func (c *Config) Float(key string) float64 {
+	switch value := c.values[key].(type) {
+	case json.Number:
+		f, _ := value.Float64()
+		return f
+	case float64:
+		return value
+	case string:
+		f, _ := strconv.ParseFloat(value, 64)
+		return f
+	default:
+		return 0
+	}
+}
+
+func (c *Config) Array(key string) []string {
+	switch value := c.values[key].(type) {
+	case nil:
+		return []string{}
+	case []any:
+		arr := make([]string, 0, len(value))
+		for _, v := range value {
+			arr = append(arr, formatConfigValue(v))
+		}
+		return arr
+	default:
+		return []string{formatConfigValue(value)}
+	}
+}
+
+func formatConfigValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case []any:
+		arr := make([]string, 0, len(v))
+		for _, item := range v {
+			arr = append(arr, formatConfigValue(item))
+		}
+		return strings.Join(arr, ",")
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+// parseIniConfig parses a subset of INI and TOML: [sections], key = value pairs and
+// comments. Values can be quoted strings, booleans, numbers or [arrays] of those.
+// Numbers are kept as json.Number, like numbers of JSON files.
+func parseIniConfig(content string) (map[string]any, error) {
+	values := make(map[string]any)
+	section := ""
+
+	for n, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			section = strings.TrimSpace(line[1 : len(line)-1])
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("expected \"key = value\" on line %d", n+1)
+		}
+
+		key = strings.Trim(strings.TrimSpace(key), `"`)
+		if section != "" {
+			key = section + "." + key
+		}
+
+		parsed, err := parseIniValue(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("invalid value on line %d: %s", n+1, err)
+		}
+
+		values[key] = parsed
+	}
+
+	return values, nil
+}
+
+func parseIniValue(value string) (any, error) {
+	if strings.HasPrefix(value, `"`) {
+		end := closingQuote(value)
+		if end == -1 {
+			return nil, errors.New("unterminated string")
+		}
+
+		if err := expectComment(value[end+1:]); err != nil {
+			return nil, err
+		}
+
+		return strconv.Unquote(value[:end+1])
+	}
+
+	if strings.HasPrefix(value, "'") {
+		end := strings.Index(value[1:], "'") + 1
+		if end == 0 {
+			return nil, errors.New("unterminated string")
+		}
+
+		if err := expectComment(value[end+1:]); err != nil {
+			return nil, err
+		}
+
+		return value[1:end], nil
+	}
+
+	if strings.HasPrefix(value, "[") {
+		end := strings.LastIndex(value, "]")
+		if end == -1 {
+			return nil, errors.New("unterminated array")
+		}
+
+		arr := make([]any, 0)
+		for _, item := range splitIniArray(value[1:end]) {
+			parsed, err := parseIniValue(item)
+			if err != nil {
+				return nil, err
+			}
+
+			arr = append(arr, parsed)
+		}
+
+		return arr, nil
+	}
+
+	// strip trailing comments of unquoted values
+	for _, marker := range []string{" #", " ;"} {
+		if idx := strings.Index(value, marker); idx != -1 {
+			value = strings.TrimSpace(value[:idx])
+		}
+	}
+
+	if value == "true" || value == "false" {
+		return value == "true", nil
+	}
+
+	var number json.Number
+	if err := json.Unmarshal([]byte(value), &number); err == nil {
+		return number, nil
+	}
+
+	return value, nil
+}
+
+// closingQuote returns the index of the quote that closes the double quoted string at
+// the start of the value, or -1 if it is not closed.
+func closingQuote(value string) int {
+	for i := 1; i < len(value); i++ {
+		switch value[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		}
+	}
+
+	return -1
+}
+
+// expectComment returns an error if the rest of a line after a value is not empty or
+// a comment.
+func expectComment(rest string) error {
+	rest = strings.TrimSpace(rest)
+	if rest == "" || strings.HasPrefix(rest, "#") || strings.HasPrefix(rest, ";") {
+		return nil
+	}
+
+	return fmt.Errorf("unexpected \"%s\" after value", rest)
+}
+
+func splitIniArray(content string) []string {
+	items := make([]string, 0)
+	var current strings.Builder
+	var quote rune
+
+	for _, r := range content {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == ',':
+			if item := strings.TrimSpace(current.String()); item != "" {
+				items = append(items, item)
+			}
+			current.Reset()
+			continue
+		}
+
+		current.WriteRune(r)
+	}
+
+	if item := strings.TrimSpace(current.String()); item != "" {
+		items = append(items, item)
+	}
+
+	return items
+}
+
+// ConfigFiles returns the configuration files that are loaded for the given
+// application name, in order of precedence from low to high: the user config in the
+// XDG config directory and the project-local file in the working directory.
+func ConfigFiles(name string) []string {
+	files := make([]string, 0, 2)
+
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if dir == "" {
+		if home, err := os.UserHomeDir(); err == nil {
+			dir = filepath.Join(home, ".config")
+		}
+	}
+
+	if dir != "" {
+		if file := findConfigFile(filepath.Join(dir, name), "config"); file != "" {
+			files = append(files, file)
+		}
+	}
+
+	if wd, err := os.Getwd(); err == nil {
+		if file := findConfigFile(wd, "."+name); file != "" {
+			files = append(files, file)
+		}
+	}
+
+	return files
+}
+
+func findConfigFile(dir string, base string) string {
+	for _, ext := range configExtensions {
+		file := filepath.Join(dir, base+ext)
+		if _, err := os.Stat(file); err == nil {
+			return file
+		}
+	}
+
+	return ""
+}
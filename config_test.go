@@ -0,0 +1,149 @@
+package cli
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestIniConfigIsParsed(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected map[string]any
+	}{
+		{
+			name:     "bare values",
+			content:  "region = eu-west\ndry-run = true\nretries = 3",
+			expected: map[string]any{"region": "eu-west", "dry-run": true, "retries": json.Number("3")},
+		},
+		{
+			name:     "large integers",
+			content:  "id = 9007199254740993",
+			expected: map[string]any{"id": json.Number("9007199254740993")},
+		},
+		{
+			name:     "double quoted strings",
+			content:  `greeting = "hello \"world\"" # comment`,
+			expected: map[string]any{"greeting": `hello "world"`},
+		},
+		{
+			name:     "single quoted strings",
+			content:  `path = 'C:\temp # not a comment' ; comment`,
+			expected: map[string]any{"path": `C:\temp # not a comment`},
+		},
+		{
+			name:     "quoted numbers",
+			content:  `version = "1.10"`,
+			expected: map[string]any{"version": "1.10"},
+		},
+		{
+			name:     "quoted keys",
+			content:  `"dry-run" = false`,
+			expected: map[string]any{"dry-run": false},
+		},
+		{
+			name:     "sections",
+			content:  "verbose = true\n[deploy]\nregion = eu\n[ deploy.prod ]\nregion = us",
+			expected: map[string]any{"verbose": true, "deploy.region": "eu", "deploy.prod.region": "us"},
+		},
+		{
+			name:     "comments",
+			content:  "# comment\n; comment\n  # indented comment\nregion = eu # trailing\nzone = a ; trailing",
+			expected: map[string]any{"region": "eu", "zone": "a"},
+		},
+		{
+			name:     "arrays",
+			content:  `tags = ["a", 'b, c', 1, true] # comment`,
+			expected: map[string]any{"tags": []any{"a", "b, c", json.Number("1"), true}},
+		},
+		{
+			name:     "empty values",
+			content:  "region =\nzone = \"\"",
+			expected: map[string]any{"region": "", "zone": ""},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			values, err := parseIniConfig(test.content)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(values, test.expected) {
+				t.Errorf("expected %#v, got %#v", test.expected, values)
+			}
+		})
+	}
+}
+
+func TestInvalidIniConfigIsRejected(t *testing.T) {
+	tests := map[string]string{
+		"missing separator":    "region",
+		"unterminated string":  `region = "eu`,
+		"unterminated quote":   `region = 'eu`,
+		"unterminated array":   `tags = ["a", "b"`,
+		"text after a string":  `region = "eu" us`,
+		"invalid escape":       `region = "\q"`,
+		"error in later lines": "region = eu\nzone",
+	}
+
+	for name, content := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := parseIniConfig(content); err == nil {
+				t.Errorf("expected an error for %q", content)
+			}
+		})
+	}
+}
+
+func TestJsonConfigKeepsNumberPrecision(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	content := `{"deploy": {"id": 9007199254740993, "ratio": 0.5}}`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	config, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if config.String("deploy.id") != "9007199254740993" {
+		t.Errorf("expected the id to keep its precision, got %q", config.String("deploy.id"))
+	}
+
+	if config.Int("deploy.id") != 9007199254740993 {
+		t.Errorf("expected the id to keep its precision, got %d", config.Int("deploy.id"))
+	}
+
+	if config.Float("deploy.ratio") != 0.5 {
+		t.Errorf("expected ratio 0.5, got %v", config.Float("deploy.ratio"))
+	}
+}
+
+func TestConfigFilesAreLayered(t *testing.T) {
+	dir := t.TempDir()
+	user := filepath.Join(dir, "config.toml")
+	project := filepath.Join(dir, ".app.json")
+
+	if err := os.WriteFile(user, []byte("region = eu\nzone = a"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(project, []byte(`{"region": "us"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	config, err := LoadConfig(user, filepath.Join(dir, "missing.ini"), project)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if config.String("region") != "us" || config.String("zone") != "a" {
+		t.Errorf("expected region \"us\" and zone \"a\", got %q and %q", config.String("region"), config.String("zone"))
+	}
+}
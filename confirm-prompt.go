@@ -4,7 +4,6 @@ import (
 	"fmt"
 
 	"github.com/michielnijenhuis/cli/helper/keys"
-	"github.com/michielnijenhuis/cli/terminal"
 )
 
 type ConfirmPrompt struct {
@@ -69,7 +68,7 @@ func NewConfirmPrompt(i *Input, o *Output, label string, defaultValue bool) *Con
 }
 
 func (cp *ConfirmPrompt) String() string {
-	renderer := NewRenderer()
+	renderer := newRenderer(cp.output.Width())
 	state := cp.State
 
 	if state == PromptStateSubmit {
@@ -88,7 +87,7 @@ func (cp *ConfirmPrompt) String() string {
 }
 
 func (cp *ConfirmPrompt) renderOptions() string {
-	terminalWidth := terminal.Columns()
+	terminalWidth := cp.output.Width()
 	length := (terminalWidth - 14) / 2
 	yes := Truncate(cp.Yes, length, "")
 	no := Truncate(cp.No, length, "")
@@ -4,36 +4,61 @@ import (
 	"fmt"
 	"slices"
 	"strings"
+	"sync"
 
 	"github.com/michielnijenhuis/cli/helper"
-	"github.com/michielnijenhuis/cli/terminal"
 )
 
 type ConsoleSectionOutput struct {
 	*Output
 	content   []string
 	lines     int
-	sections  []*ConsoleSectionOutput
+	sections  *[]*ConsoleSectionOutput
 	maxHeight int
+	mu        *sync.Mutex
 }
 
+// NewConsoleSectionOutput creates a section writing to the stream of o. The section is
+// added to the given sections, which are redrawn when a section before them changes.
+// Use Output.CreateSection for sections that are shared between goroutines.
 func NewConsoleSectionOutput(o *Output, sections []*ConsoleSectionOutput) *ConsoleSectionOutput {
-	if sections == nil {
-		sections = make([]*ConsoleSectionOutput, 0)
-	}
+	return newConsoleSectionOutput(o, &sections, &sync.Mutex{})
+}
+
+// newConsoleSectionOutput creates a section that is added to the shared sections,
+// which are guarded by mu.
+func newConsoleSectionOutput(o *Output, sections *[]*ConsoleSectionOutput, mu *sync.Mutex) *ConsoleSectionOutput {
+	// every section gets its own formatter, as formatting is stateful and
+	// sections may be written to from different goroutines
+	o.formatter.init()
+	so := setupNewOutput(o.input, o.Stream, o.formatter.Clone())
+	so.SetVerbosity(o.verbosity)
+	so.SetDecorated(o.IsDecorated())
+	// a section wraps like its parent, which may have been pinned to a size
+	so.SetSize(o.width, o.height)
+	so.lineLength = o.lineLength
+	so.sections = sections
+	so.sectionsMu = mu
 
 	cso := &ConsoleSectionOutput{
-		Output: o,
+		Output:   so,
+		sections: sections,
+		mu:       mu,
 	}
 
-	helper.Unshift(&sections, cso)
+	so.section = cso
 
-	cso.sections = sections
+	mu.Lock()
+	helper.Unshift(sections, cso)
+	mu.Unlock()
 
 	return cso
 }
 
 func (c *ConsoleSectionOutput) SetMaxHeight(maxHeight int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	prev := c.maxHeight
 	c.maxHeight = maxHeight
 
@@ -44,17 +69,27 @@ func (c *ConsoleSectionOutput) SetMaxHeight(maxHeight int) {
 		existingContent = c.popStreamContentUntilCurrentSection(c.lines)
 	}
 
-	c.Output.DoWrite(c.VisibleContent(), false)
-	c.Output.DoWrite(existingContent, false)
+	c.Output.write(c.VisibleContent(), false)
+	c.Output.write(existingContent, false)
 }
 
+// Clear removes the given amount of lines from the section. A value of 0 or less
+// clears the whole section; 0 used to remove a single line without updating the
+// number of lines the section spans, so it is no longer accepted for that.
 func (c *ConsoleSectionOutput) Clear(lines int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.clear(lines)
+}
+
+func (c *ConsoleSectionOutput) clear(lines int) {
 	if len(c.content) == 0 || !c.IsDecorated() {
 		return
 	}
 
-	if lines >= 0 {
-		c.content = c.content[:len(c.content)-lines-1]
+	if lines > 0 {
+		c.content = c.content[:max(0, len(c.content)-lines)]
 	} else {
 		lines = c.lines
 		c.content = make([]string, 0)
@@ -64,22 +99,26 @@ func (c *ConsoleSectionOutput) Clear(lines int) {
 
 	var existingContent string
 	if c.maxHeight != 0 {
-		existingContent = c.popStreamContentUntilCurrentSection(min(c.maxHeight, c.lines))
+		existingContent = c.popStreamContentUntilCurrentSection(min(c.maxHeight, lines))
 	} else {
-		existingContent = c.popStreamContentUntilCurrentSection(c.lines)
+		existingContent = c.popStreamContentUntilCurrentSection(lines)
 	}
 
-	c.Output.DoWrite(existingContent, false)
+	c.Output.write(existingContent, false)
 }
 
 func (c *ConsoleSectionOutput) Overwrite(message string) {
-	c.Clear(-1)
-	c.Writeln(message, 0)
+	c.OverwriteMany([]string{message})
 }
 
-func (c *ConsoleSectionOutput) OverwriteMany(message []string) {
-	c.Clear(-1)
-	c.Writelns(message, 0)
+func (c *ConsoleSectionOutput) OverwriteMany(messages []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.clear(-1)
+	for _, message := range messages {
+		c.doWrite(c.Formatter().Format(message), true)
+	}
 }
 
 func (c *ConsoleSectionOutput) Content() string {
@@ -91,11 +130,11 @@ func (c *ConsoleSectionOutput) VisibleContent() string {
 		return c.Content()
 	}
 
-	return strings.Join(c.content[:len(c.content)-1-c.maxHeight], "")
+	return strings.Join(c.content[max(0, len(c.content)-c.maxHeight):], "")
 }
 
 func (c *ConsoleSectionOutput) AddContent(input string, newLine bool) int {
-	width := terminal.Columns()
+	width := c.Width()
 	lines := strings.Split(input, Eol)
 	linesAdded := 0
 	count := len(lines) - 1
@@ -111,14 +150,14 @@ func (c *ConsoleSectionOutput) AddContent(input string, newLine bool) int {
 
 		if i == 0 && len(c.content) > 0 && !strings.HasSuffix(c.content[len(c.content)-1], Eol) {
 			lastLine := c.content[len(c.content)-1]
-			c.lines -= max(1, c.displayLength(lastLine)/width)
+			c.lines -= c.lineCount(lastLine, width)
 			lineContent = lastLine + lineContent
 			c.content[len(c.content)-1] = lineContent
 		} else {
 			c.content = append(c.content, lineContent)
 		}
 
-		linesAdded += max(1, c.displayLength(lineContent)/width)
+		linesAdded += c.lineCount(lineContent, width)
 	}
 
 	c.lines += linesAdded
@@ -127,18 +166,28 @@ func (c *ConsoleSectionOutput) AddContent(input string, newLine bool) int {
 }
 
 func (c *ConsoleSectionOutput) AddNewLineOfInputSubmit() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	c.content = append(c.content, Eol)
 	c.lines++
 }
 
 func (c *ConsoleSectionOutput) DoWrite(message string, newLine bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.doWrite(message, newLine)
+}
+
+func (c *ConsoleSectionOutput) doWrite(message string, newLine bool) {
 	if !newLine && strings.HasSuffix(message, Eol) {
 		message = message[:len(message)-len(Eol)]
 		newLine = true
 	}
 
 	if !c.Output.IsDecorated() {
-		c.Output.DoWrite(message, newLine)
+		c.Output.write(message, newLine)
 		return
 	}
 
@@ -159,30 +208,34 @@ func (c *ConsoleSectionOutput) DoWrite(message string, newLine bool) {
 
 	lineOverflow := c.maxHeight > 0 && c.lines > c.maxHeight
 	if lineOverflow {
+		// on overflow, clear the whole section and redraw again
+		// (to remove lines that exceed the maximum height)
 		linesToClear = c.maxHeight
 	}
 
 	erasedContent := c.popStreamContentUntilCurrentSection(linesToClear)
 
 	if lineOverflow {
-		previousLinesOfSection := c.content[c.lines-c.maxHeight : c.maxHeight-linesAdded]
-		c.Output.DoWrite(strings.Join(previousLinesOfSection, ""), false)
+		// redraw existing lines of the section
+		start := max(0, min(len(c.content), c.lines-c.maxHeight))
+		end := max(start, min(len(c.content), start+c.maxHeight-linesAdded))
+		c.Output.write(strings.Join(c.content[start:end], ""), false)
 	}
 
 	if deleteLastLine {
-		c.Output.DoWrite(lastLine+message, true)
+		c.Output.write(lastLine+message, true)
 	} else {
-		c.Output.DoWrite(message, true)
+		c.Output.write(message, true)
 	}
 
-	c.Output.DoWrite(erasedContent, false)
+	c.Output.write(erasedContent, false)
 }
 
 func (c *ConsoleSectionOutput) popStreamContentUntilCurrentSection(numberOfLinesToClearFromCurrentSection int) string {
 	numberOfLinesToClear := numberOfLinesToClearFromCurrentSection
 	erasedContent := make([]string, 0)
 
-	for _, section := range c.sections {
+	for _, section := range *c.sections {
 		if section == c {
 			break
 		}
@@ -203,14 +256,23 @@ func (c *ConsoleSectionOutput) popStreamContentUntilCurrentSection(numberOfLines
 	}
 
 	if numberOfLinesToClear > 0 {
-		c.Output.DoWrite(fmt.Sprintf("\x1b[%dA", numberOfLinesToClear), false)
-		c.Output.DoWrite("\x1b[0J", false)
+		c.Output.write(fmt.Sprintf("\x1b[%dA", numberOfLinesToClear), false)
+		c.Output.write("\x1b[0J", false)
 	}
 
 	slices.Reverse(erasedContent)
 	return strings.Join(erasedContent, "")
 }
 
+func (c *ConsoleSectionOutput) lineCount(text string, width int) int {
+	if width <= 0 {
+		return 1
+	}
+
+	length := c.displayLength(strings.TrimSuffix(text, Eol))
+	return max(1, (length+width-1)/width)
+}
+
 func (c *ConsoleSectionOutput) displayLength(text string) int {
 	f := c.Formatter()
 	return helper.Width(f.RemoveDecoration(strings.ReplaceAll(text, "\t", "        ")))
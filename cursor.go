@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"io"
 	"math"
-	"os"
 	"os/exec"
 	"regexp"
 	"strconv"
@@ -15,7 +14,7 @@ import (
 
 type Cursor struct {
 	Output         *Output
-	Input          *os.File
+	Input          io.Reader
 	isTtySupported uint8 // 0 not set, 1 true, 2 false
 }
 
@@ -137,7 +136,12 @@ func (c *Cursor) CurrentPosition() (int, int) {
 		return 1, 1
 	}
 
-	_, err = c.Input.WriteString("\x1b[6bn")
+	w, ok := c.Input.(io.Writer)
+	if !ok {
+		return 1, 1
+	}
+
+	_, err = io.WriteString(w, "\x1b[6bn")
 	if err != nil {
 		return 1, 1
 	}
@@ -3,6 +3,7 @@ package cli
 import (
 	"fmt"
 	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/michielnijenhuis/cli/helper"
@@ -10,7 +11,41 @@ import (
 )
 
 type DescriptorOptions struct {
-	totalWidth int
+	totalWidth  int
+	globalFlags []Flag
+	envVars     []string
+}
+
+// Descriptor describes commands and their input in a specific format.
+type Descriptor interface {
+	DescribeCommand(command *Command, options *DescriptorOptions)
+	DescribeInputDefinition(definition *InputDefinition, options *DescriptorOptions)
+	DescribeArgument(argument Arg, options *DescriptorOptions)
+	DescribeFlag(flag Flag, options *DescriptorOptions)
+}
+
+var descriptors = map[string]func(o *Output) Descriptor{
+	"txt":  func(o *Output) Descriptor { return &TextDescriptor{o} },
+	"json": func(o *Output) Descriptor { return &JsonDescriptor{o} },
+	"xml":  func(o *Output) Descriptor { return &XmlDescriptor{o} },
+	"md":   func(o *Output) Descriptor { return &MarkdownDescriptor{o} },
+}
+
+func RegisterDescriptor(format string, factory func(o *Output) Descriptor) {
+	descriptors[format] = factory
+}
+
+func DescriptorFormats() []string {
+	return array.SortedKeys(descriptors)
+}
+
+func NewDescriptor(format string, o *Output) (Descriptor, error) {
+	factory, ok := descriptors[format]
+	if !ok {
+		return nil, fmt.Errorf("unsupported format \"%s\". Expected one of: %s", format, strings.Join(DescriptorFormats(), ", "))
+	}
+
+	return factory(o), nil
 }
 
 type TextDescriptor struct {
@@ -23,7 +58,10 @@ func (d *TextDescriptor) DescribeCommand(command *Command, options *DescriptorOp
 		d.writeText(intro + Eol + Eol)
 	}
 
-	definition, _ := command.Definition()
+	definition, err := command.Definition()
+	if err != nil {
+		definition = &InputDefinition{}
+	}
 
 	d.writeText("<primary>Usage:</primary>")
 	d.writeText(Eol)
@@ -56,41 +94,15 @@ func (d *TextDescriptor) DescribeCommand(command *Command, options *DescriptorOp
 
 	d.writeText(Eol + Eol)
 
+	if options == nil {
+		options = &DescriptorOptions{}
+	}
+	options.globalFlags, _ = command.InheritedFlags()
+
 	d.DescribeInputDefinition(definition, options)
 
 	if command.HasSubcommands() {
-		commands := command.All()
-
-		d.writeText(Eol)
-		d.writeText(Eol)
-		d.writeText("<primary>Available commands:</primary>")
-
-		commandNames := array.SortedKeys(commands)
-		width := 0
-		for _, name := range commandNames {
-			cmd := commands[name]
-			if !cmd.Hidden {
-				width = max(width, len(name))
-			}
-		}
-
-		for _, name := range commandNames {
-			cmd := commands[name]
-			if cmd.Hidden {
-				continue
-			}
-
-			d.writeText(Eol)
-			spacingWidth := width - helper.Width(name)
-			command := commands[name]
-
-			var commandAliases string
-			if name == command.Name {
-				commandAliases = d.commandAliasesText(cmd)
-			}
-
-			d.writeText(fmt.Sprintf("  <accent>%s</accent>%s%s%s", name, strings.Repeat(" ", max(spacingWidth, 0)+2), commandAliases, command.Description))
-		}
+		d.describeCommandGroups(command.GroupedSubcommands())
 	}
 
 	d.writeText(Eol)
@@ -105,14 +117,65 @@ func (d *TextDescriptor) DescribeCommand(command *Command, options *DescriptorOp
 	}
 }
 
+func (d *TextDescriptor) describeCommandGroups(groups []GroupedCommands) {
+	width := 0
+	for _, group := range groups {
+		for _, cmd := range group.Commands {
+			width = max(width, helper.Width(cmd.Name))
+		}
+	}
+
+	for _, group := range groups {
+		title := group.Group.Title
+		if title == "" {
+			title = group.Group.ID
+		}
+		if title == "" {
+			title = "Available commands"
+		}
+
+		d.writeText(Eol)
+		d.writeText(Eol)
+		d.writeText(fmt.Sprintf("<primary>%s:</primary>", title))
+
+		for _, cmd := range group.Commands {
+			d.writeText(Eol)
+			spacingWidth := width - helper.Width(cmd.Name)
+			commandAliases := d.commandAliasesText(cmd)
+
+			d.writeText(fmt.Sprintf("  <accent>%s</accent>%s%s%s", cmd.Name, strings.Repeat(" ", max(spacingWidth, 0)+2), commandAliases, cmd.Description))
+		}
+	}
+}
+
 func (d *TextDescriptor) DescribeInputDefinition(definition *InputDefinition, options *DescriptorOptions) {
-	totalWidth := calculateTotalWidthForFlags(definition.flags)
+	var inheritedFlags []Flag
+	if options != nil {
+		inheritedFlags = options.globalFlags
+	}
+
+	flags := make([]Flag, 0, len(definition.flags))
+	globalFlags := make([]Flag, 0, len(inheritedFlags))
+	for _, flag := range definition.flags {
+		if FlagIsDeprecated(flag) {
+			continue
+		}
+
+		if slices.Contains(inheritedFlags, flag) {
+			globalFlags = append(globalFlags, flag)
+		} else {
+			flags = append(flags, flag)
+		}
+	}
+
+	totalWidth := calculateTotalWidthForFlags(slices.Concat(flags, globalFlags))
 	for _, argument := range definition.arguments {
 		totalWidth = max(totalWidth, helper.Width(argument.GetName()))
 	}
 
 	hasArgs := len(definition.arguments) > 0
-	hasFlags := len(definition.flags) > 0
+	hasFlags := len(flags) > 0
+	hasGlobalFlags := len(globalFlags) > 0
 
 	if hasArgs {
 		d.writeText("<primary>Arguments:</primary>")
@@ -123,39 +186,80 @@ func (d *TextDescriptor) DescribeInputDefinition(definition *InputDefinition, op
 				totalWidth: totalWidth,
 			})
 
-			if i+1 < len(definition.arguments) || hasFlags {
+			if i+1 < len(definition.arguments) || hasFlags || hasGlobalFlags {
 				d.writeText(Eol)
 			}
 		}
 	}
 
-	if hasArgs && hasFlags {
+	if hasArgs && (hasFlags || hasGlobalFlags) {
 		d.writeText(Eol)
 	}
 
 	if hasFlags {
-		laterFlags := make([]Flag, 0)
+		d.describeFlags("Flags", flags, definition, totalWidth)
+	}
 
-		d.writeText("<primary>Flags:</primary>")
+	if hasFlags && hasGlobalFlags {
+		d.writeText(Eol + Eol)
+	}
 
-		for _, flag := range definition.flags {
-			if len(flag.GetShortcutString()) > 1 {
-				laterFlags = append(laterFlags, flag)
-				continue
-			}
+	if hasGlobalFlags {
+		d.describeFlags("Global flags", globalFlags, definition, totalWidth)
+	}
 
-			d.writeText(Eol)
-			d.DescribeFlag(flag, &DescriptorOptions{
-				totalWidth: totalWidth,
-			})
+	if groups := definition.FlagGroups(); len(groups) > 0 {
+		d.writeText(Eol + Eol)
+		d.describeFlagGroups(groups)
+	}
+}
+
+func (d *TextDescriptor) describeFlagGroups(groups []FlagGroup) {
+	d.writeText("<primary>Flag groups:</primary>")
+
+	names := make([]string, 0, len(groups))
+	width := 0
+	for _, group := range groups {
+		flags := make([]string, 0, len(group.Flags))
+		for _, name := range group.Flags {
+			flags = append(flags, "--"+name)
 		}
 
-		for _, flag := range laterFlags {
-			d.writeText(Eol)
-			d.DescribeFlag(flag, &DescriptorOptions{
-				totalWidth: totalWidth,
-			})
+		names = append(names, strings.Join(flags, ", "))
+		width = max(width, helper.Width(names[len(names)-1]))
+	}
+
+	for i, group := range groups {
+		d.writeText(Eol)
+		spacingWidth := width - helper.Width(names[i])
+		d.writeText(fmt.Sprintf("  <accent>%s</accent>  %s%s", names[i], strings.Repeat(" ", spacingWidth), group.Description()))
+	}
+}
+
+func (d *TextDescriptor) describeFlags(title string, flags []Flag, definition *InputDefinition, totalWidth int) {
+	laterFlags := make([]Flag, 0)
+
+	d.writeText(fmt.Sprintf("<primary>%s:</primary>", title))
+
+	for _, flag := range flags {
+		if len(flag.GetShortcutString()) > 1 {
+			laterFlags = append(laterFlags, flag)
+			continue
 		}
+
+		d.writeText(Eol)
+		d.DescribeFlag(flag, &DescriptorOptions{
+			totalWidth: totalWidth,
+			envVars:    definition.EnvVars(flag.GetName()),
+		})
+	}
+
+	for _, flag := range laterFlags {
+		d.writeText(Eol)
+		d.DescribeFlag(flag, &DescriptorOptions{
+			totalWidth: totalWidth,
+			envVars:    definition.EnvVars(flag.GetName()),
+		})
 	}
 }
 
@@ -191,6 +295,10 @@ func argHasDefaultValue(arg Arg) bool {
 	if a, ok := arg.(*ArrayArg); ok {
 		return len(a.Value) > 0
 	}
+	switch arg.(type) {
+	case *IntArg, *UintArg, *FloatArg, *DurationArg, *TimeArg:
+		return arg.HasValue()
+	}
 	return false
 }
 
@@ -200,39 +308,31 @@ func formatArgValue(arg Arg) string {
 		return formatDefaultValue(a.Value)
 	case *ArrayArg:
 		return formatDefaultValue(a.Value)
+	case *IntArg, *UintArg, *FloatArg, *DurationArg, *TimeArg:
+		return formatDefaultValue(GetArgStringValue(a))
 	default:
 		return ""
 	}
 }
 
 func formatFlagValue(flag Flag) string {
-	switch f := flag.(type) {
-	case *StringFlag:
-		return formatDefaultValue(f.Value)
-	case *ArrayFlag:
-		return formatDefaultValue(f.Value)
-	case *BoolFlag:
-		return formatDefaultValue(f.Value)
-	case *OptionalStringFlag:
-		v := formatDefaultValue(f.Value)
-		if v != "" {
-			return v
-		}
-		return formatDefaultValue(f.Boolean)
-	case *OptionalArrayFlag:
-		v := formatDefaultValue(f.Value)
-		if v != "" {
-			return v
-		}
-		return formatDefaultValue(f.Boolean)
+	switch {
+	case FlagIsArray(flag):
+		return formatDefaultValue(GetFlagArrayValue(flag))
+	case !FlagAcceptsValue(flag):
+		return formatDefaultValue(GetFlagBoolValue(flag))
+	case FlagValueIsOptional(flag) && GetFlagStringValue(flag) == "":
+		return formatDefaultValue(GetFlagBoolValue(flag))
 	default:
-		return ""
+		return formatDefaultValue(GetFlagStringValue(flag))
 	}
 }
 
 func (d *TextDescriptor) DescribeFlag(flag Flag, options *DescriptorOptions) {
 	var defaultValue string
-	if FlagHasDefaultValue(flag) {
+	if FlagIsRequired(flag) {
+		defaultValue = "<primary> [required]</primary>"
+	} else if FlagHasDefaultValue(flag) {
 		defaultValue = fmt.Sprintf("<primary> [default: %s]</primary>", formatFlagValue(flag))
 	}
 
@@ -274,12 +374,17 @@ func (d *TextDescriptor) DescribeFlag(flag Flag, options *DescriptorOptions) {
 	re := regexp.MustCompile(`\s*[\r\n]\s*`)
 	desc := re.ReplaceAllString(flag.GetDescription(), strings.Repeat(" ", totalWidth+4))
 
+	var env string
+	if options != nil && len(options.envVars) > 0 {
+		env = fmt.Sprintf("<primary> [env: %s]</primary>", strings.Join(options.envVars, ", "))
+	}
+
 	var arr string
 	if FlagIsArray(flag) {
 		arr = "<primary> (multiple values allowed)</primary>"
 	}
 
-	d.writeText(fmt.Sprintf("  <accent>%s</accent>  %s%s%s%s", synopsisString, width, desc, defaultValue, arr))
+	d.writeText(fmt.Sprintf("  <accent>%s</accent>  %s%s%s%s%s", synopsisString, width, desc, defaultValue, env, arr))
 }
 
 func calculateTotalWidthForFlags(flags []Flag) int {
@@ -1,5 +1,84 @@
 package cli
 
+import (
+	"context"
+	"errors"
+)
+
+// ErrInterrupted is the cause of the command context being cancelled after
+// receiving an interrupt or termination signal, or when a prompt is cancelled.
+var ErrInterrupted = errors.New("interrupted")
+
+const (
+	ExitSuccess     = 0
+	ExitFailure     = 1
+	ExitUsage       = 2
+	ExitInterrupted = 130
+)
+
+// ExitCoder is an error that determines the exit code of the process.
+type ExitCoder interface {
+	error
+	ExitCode() int
+}
+
+type exitError struct {
+	code int
+	err  error
+}
+
+// Exit returns an error that exits the process with the given code. A nil error exits
+// with the code without rendering an error message.
+func Exit(code int, err error) error {
+	return &exitError{
+		code: code,
+		err:  err,
+	}
+}
+
+func (e *exitError) Error() string {
+	if e.err == nil {
+		return ""
+	}
+
+	return e.err.Error()
+}
+
+func (e *exitError) Unwrap() error {
+	return e.err
+}
+
+func (e *exitError) ExitCode() int {
+	return e.code
+}
+
+// ExitCode returns the exit code for the given error. Errors implementing ExitCoder
+// determine their own code, cancellations exit with ExitInterrupted and any other
+// error with ExitFailure.
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitSuccess
+	}
+
+	var coder ExitCoder
+	if errors.As(err, &coder) {
+		return coder.ExitCode()
+	}
+
+	if errors.Is(err, ErrInterrupted) || errors.Is(err, context.Canceled) {
+		return ExitInterrupted
+	}
+
+	return ExitFailure
+}
+
+// isSilentExit reports whether the error only carries an exit code, and has no
+// message to render.
+func isSilentExit(err error) bool {
+	var e *exitError
+	return errors.As(err, &e) && e.err == nil
+}
+
 type ErrorWithAlternatives interface {
 	error
 	Alternatives() []string
@@ -28,3 +107,7 @@ func (e *CommandNotFoundError) Error() string {
 func (e *CommandNotFoundError) Alternatives() []string {
 	return e.alternatives
 }
+
+func (e *CommandNotFoundError) ExitCode() int {
+	return ExitUsage
+}
@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"testing"
+)
+
+func TestExitCodes(t *testing.T) {
+	tests := []struct {
+		name      string
+		commands  []*Command
+		args      []string
+		cancelled bool
+		code      int
+		silent    bool
+	}{
+		{
+			name:     "success",
+			commands: []*Command{{Name: "deploy", Run: func(io *IO) {}}},
+			args:     []string{"deploy"},
+			code:     ExitSuccess,
+		},
+		{
+			name:     "unknown flag",
+			commands: []*Command{{Name: "deploy", Run: func(io *IO) {}}},
+			args:     []string{"deploy", "--zone=eu"},
+			code:     ExitUsage,
+		},
+		{
+			name:     "missing flag value",
+			commands: []*Command{{Name: "deploy", Flags: []Flag{&StringFlag{Name: "region"}}, Run: func(io *IO) {}}},
+			args:     []string{"deploy", "--region"},
+			code:     ExitUsage,
+		},
+		{
+			name:     "unknown command",
+			commands: []*Command{{Name: "deploy", Run: func(io *IO) {}}},
+			args:     []string{"xyz"},
+			code:     ExitUsage,
+		},
+		{
+			name:     "ambiguous command",
+			commands: []*Command{{Name: "deploy", Run: func(io *IO) {}}, {Name: "delete", Run: func(io *IO) {}}},
+			args:     []string{"de"},
+			code:     ExitUsage,
+		},
+		{
+			name: "exit without error",
+			commands: []*Command{{Name: "fail", RunE: func(io *IO) error {
+				return Exit(3, nil)
+			}}},
+			args:   []string{"fail"},
+			code:   3,
+			silent: true,
+		},
+		{
+			name: "cancelled context",
+			commands: []*Command{{Name: "wait", RunE: func(io *IO) error {
+				<-io.Context().Done()
+				return io.Context().Err()
+			}}},
+			args:      []string{"wait"},
+			cancelled: true,
+			code:      ExitInterrupted,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var out bytes.Buffer
+			app := &Command{Name: "app", Strict: true, Commands: test.commands}
+			app.SetOut(&out)
+			app.SetErr(&out)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			if test.cancelled {
+				cancel()
+			}
+			defer cancel()
+
+			if code, _ := app.ExecuteContext(ctx, test.args...); code != test.code {
+				t.Errorf("expected exit code %d, got %d: %s", test.code, code, out.String())
+			}
+
+			if test.silent && out.Len() > 0 {
+				t.Errorf("expected no output, got %q", out.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package cli
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+type FlagGroupKind int
+
+const (
+	// FlagGroupExclusive allows at most one of the flags to be given.
+	FlagGroupExclusive FlagGroupKind = iota
+	// FlagGroupAllOrNone requires either all or none of the flags to be given.
+	FlagGroupAllOrNone
+	// FlagGroupAtLeastOne requires at least one of the flags to be given.
+	FlagGroupAtLeastOne
+)
+
+type FlagGroup struct {
+	Kind  FlagGroupKind
+	Flags []string
+}
+
+func (g FlagGroup) Description() string {
+	switch g.Kind {
+	case FlagGroupExclusive:
+		return "Mutually exclusive"
+	case FlagGroupAllOrNone:
+		return "Must be used together"
+	case FlagGroupAtLeastOne:
+		return "At least one is required"
+	default:
+		return ""
+	}
+}
+
+// Validate checks the group against the names of the flags that were given.
+func (g FlagGroup) Validate(given func(name string) bool) error {
+	present := make([]string, 0, len(g.Flags))
+	missing := make([]string, 0, len(g.Flags))
+
+	for _, name := range g.Flags {
+		if given(name) {
+			present = append(present, name)
+		} else {
+			missing = append(missing, name)
+		}
+	}
+
+	switch g.Kind {
+	case FlagGroupExclusive:
+		if len(present) > 1 {
+			return fmt.Errorf("the flags %s cannot be used together", formatFlagNames(present, "and"))
+		}
+	case FlagGroupAllOrNone:
+		if len(present) > 0 && len(missing) > 0 {
+			return fmt.Errorf("the flags %s must be used together (missing: %s)", formatFlagNames(g.Flags, "and"), formatFlagNames(missing, "and"))
+		}
+	case FlagGroupAtLeastOne:
+		if len(present) == 0 {
+			return fmt.Errorf("at least one of the flags %s is required", formatFlagNames(g.Flags, "or"))
+		}
+	}
+
+	return nil
+}
+
+// Excludes reports whether the flag with the given name can no longer be used, because
+// another flag of an exclusive group was given.
+func (g FlagGroup) Excludes(name string, given func(name string) bool) bool {
+	if g.Kind != FlagGroupExclusive || !slices.Contains(g.Flags, name) {
+		return false
+	}
+
+	for _, other := range g.Flags {
+		if other != name && given(other) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func formatFlagNames(names []string, conjunction string) string {
+	quoted := make([]string, 0, len(names))
+	for _, name := range names {
+		quoted = append(quoted, fmt.Sprintf("\"--%s\"", name))
+	}
+
+	if len(quoted) == 1 {
+		return quoted[0]
+	}
+
+	return strings.Join(quoted[:len(quoted)-1], ", ") + " " + conjunction + " " + quoted[len(quoted)-1]
+}
@@ -4,7 +4,9 @@ import (
 	"errors"
 	"fmt"
 	"slices"
+	"strconv"
 	"strings"
+	"time"
 )
 
 type Flag interface {
@@ -20,129 +22,390 @@ type HasOptions interface {
 	Opts() []string
 }
 
+type HasEnvVars interface {
+	GetEnvVars() []string
+}
+
+type CanBeRequired interface {
+	IsRequired() bool
+}
+
+type CanBeDeprecated interface {
+	GetDeprecated() string
+	GetReplacedBy() string
+}
+
 type StringFlag struct {
-	Name        string
-	Shortcuts   []string
-	Description string
-	Value       string
-	Validator   func(string) error
-	Options     []string
-	given       bool
+	Name         string
+	Shortcuts    []string
+	Description  string
+	EnvVars      []string
+	Required     bool
+	Deprecated   string
+	ReplacedBy   string
+	Value        string
+	Validator    func(string) error
+	CompleteFunc CompletionFunc
+	Options      []string
+	flagState
 }
 
 type BoolFlag struct {
 	Name        string
 	Shortcuts   []string
 	Description string
+	EnvVars     []string
+	Required    bool
+	Deprecated  string
+	ReplacedBy  string
 	Value       bool
 	Negatable   bool
 	Validator   func(bool) error
-	given       bool
+	flagState
 }
 
+// ArrayFlag accepts a value every time it is given. The values given replace the
+// default Value instead of being appended to it.
 type ArrayFlag struct {
-	Name        string
-	Shortcuts   []string
-	Description string
-	Value       []string
-	Validator   func([]string) error
-	Options     []string
-	given       bool
+	Name         string
+	Shortcuts    []string
+	Description  string
+	EnvVars      []string
+	Required     bool
+	Deprecated   string
+	ReplacedBy   string
+	Value        []string
+	Validator    func([]string) error
+	CompleteFunc CompletionFunc
+	Options      []string
+	flagState
 }
 
 type OptionalStringFlag struct {
-	Name        string
-	Shortcuts   []string
-	Description string
-	Boolean     bool
-	Value       string
-	Validator   func(bool, string) error
-	Options     []string
-	given       bool
+	Name         string
+	Shortcuts    []string
+	Description  string
+	EnvVars      []string
+	Required     bool
+	Deprecated   string
+	ReplacedBy   string
+	Boolean      bool
+	Value        string
+	Validator    func(bool, string) error
+	CompleteFunc CompletionFunc
+	Options      []string
+	flagState
 }
 
+// OptionalArrayFlag is an ArrayFlag that may also be given without a value, which sets
+// Boolean. The values given replace the default Value.
 type OptionalArrayFlag struct {
-	Name        string
-	Shortcuts   []string
-	Description string
-	Boolean     bool
-	Value       []string
-	Validator   func(bool, []string) error
-	Options     []string
-	given       bool
+	Name         string
+	Shortcuts    []string
+	Description  string
+	EnvVars      []string
+	Required     bool
+	Deprecated   string
+	ReplacedBy   string
+	Boolean      bool
+	Value        []string
+	Validator    func(bool, []string) error
+	CompleteFunc CompletionFunc
+	Options      []string
+	flagState
 }
 
-func SetFlagValue(f Flag, str string, boolean bool) {
+type IntFlag struct {
+	Name         string
+	Shortcuts    []string
+	Description  string
+	EnvVars      []string
+	Required     bool
+	Deprecated   string
+	ReplacedBy   string
+	Value        int
+	Validator    func(int) error
+	CompleteFunc CompletionFunc
+	flagState
+}
+
+type UintFlag struct {
+	Name         string
+	Shortcuts    []string
+	Description  string
+	EnvVars      []string
+	Required     bool
+	Deprecated   string
+	ReplacedBy   string
+	Value        uint
+	Validator    func(uint) error
+	CompleteFunc CompletionFunc
+	flagState
+}
+
+type FloatFlag struct {
+	Name         string
+	Shortcuts    []string
+	Description  string
+	EnvVars      []string
+	Required     bool
+	Deprecated   string
+	ReplacedBy   string
+	Value        float64
+	Validator    func(float64) error
+	CompleteFunc CompletionFunc
+	flagState
+}
+
+type DurationFlag struct {
+	Name         string
+	Shortcuts    []string
+	Description  string
+	EnvVars      []string
+	Required     bool
+	Deprecated   string
+	ReplacedBy   string
+	Value        time.Duration
+	Validator    func(time.Duration) error
+	CompleteFunc CompletionFunc
+	flagState
+}
+
+type TimeFlag struct {
+	Name         string
+	Shortcuts    []string
+	Description  string
+	EnvVars      []string
+	Required     bool
+	Deprecated   string
+	ReplacedBy   string
+	Value        time.Time
+	Layout       string
+	Validator    func(time.Time) error
+	CompleteFunc CompletionFunc
+	flagState
+}
+
+// flagState is the parse state of a flag.
+type flagState struct {
+	given bool
+	err   error
+	// restore restores the value the flag had when it was first bound
+	restore func()
+}
+
+func (s *flagState) WasGiven() bool {
+	return s.given
+}
+
+func (s *flagState) state() *flagState {
+	return s
+}
+
+type statefulFlag interface {
+	state() *flagState
+}
+
+// resetFlag resets the parse state of the flag and restores the value it had when it was
+// first bound, so that a command can be executed more than once.
+func resetFlag(f Flag) {
+	s, ok := f.(statefulFlag)
+	if !ok {
+		return
+	}
+
+	state := s.state()
+	state.given = false
+	state.err = nil
+
+	if state.restore == nil {
+		state.restore = flagRestorer(f)
+	} else {
+		state.restore()
+	}
+}
+
+// flagRestorer returns a function that restores the current value of the flag.
+func flagRestorer(f Flag) func() {
 	switch flag := f.(type) {
 	case *StringFlag:
-		flag.given = true
-		flag.Value = str
+		return restorer(&flag.Value)
 	case *BoolFlag:
-		flag.given = true
-		flag.Value = boolean
+		return restorer(&flag.Value)
 	case *ArrayFlag:
-		flag.given = true
-		if flag.Value == nil {
-			flag.Value = make([]string, 0)
-		}
-		flag.Value = append(flag.Value, str)
+		return sliceRestorer(&flag.Value)
 	case *OptionalStringFlag:
-		flag.given = true
-		flag.Value = str
-		flag.Boolean = boolean
+		value, boolean := restorer(&flag.Value), restorer(&flag.Boolean)
+		return func() {
+			value()
+			boolean()
+		}
 	case *OptionalArrayFlag:
-		flag.given = true
+		value, boolean := sliceRestorer(&flag.Value), restorer(&flag.Boolean)
+		return func() {
+			value()
+			boolean()
+		}
+	case *IntFlag:
+		return restorer(&flag.Value)
+	case *UintFlag:
+		return restorer(&flag.Value)
+	case *FloatFlag:
+		return restorer(&flag.Value)
+	case *DurationFlag:
+		return restorer(&flag.Value)
+	case *TimeFlag:
+		return restorer(&flag.Value)
+	case *ValueFlag:
 		if flag.Value == nil {
-			flag.Value = make([]string, 0)
+			return func() {}
+		}
+
+		if s, ok := flag.Value.(SliceValue); ok && s.IsSlice() {
+			values := slices.Clone(s.GetSlice())
+			return func() {
+				_ = s.Replace(values)
+			}
+		}
+
+		value := flag.Value.String()
+		return func() {
+			_ = flag.Value.Set(value)
 		}
-		flag.Value = append(flag.Value, str)
-		flag.Boolean = boolean
 	default:
-		return
+		return func() {}
 	}
 }
 
-func GetFlagStringValue(f any) string {
+func restorer[T any](value *T) func() {
+	initial := *value
+	return func() {
+		*value = initial
+	}
+}
+
+func sliceRestorer[T any](value *[]T) func() {
+	initial := slices.Clone(*value)
+	return func() {
+		*value = slices.Clone(initial)
+	}
+}
+
+// flagValue returns the Value that the flag is parsed into.
+func flagValue(f Flag) Value {
 	switch flag := f.(type) {
 	case *StringFlag:
-		return flag.Value
+		return (*stringValue)(&flag.Value)
+	case *BoolFlag:
+		return (*boolValue)(&flag.Value)
+	case *ArrayFlag:
+		return &stringSliceValue{value: &flag.Value, changed: flag.given}
 	case *OptionalStringFlag:
+		return &optionalStringValue{value: &flag.Value, boolean: &flag.Boolean}
+	case *OptionalArrayFlag:
+		return &optionalSliceValue{stringSliceValue{value: &flag.Value, changed: flag.given}, &flag.Boolean}
+	case *IntFlag:
+		return (*intValue)(&flag.Value)
+	case *UintFlag:
+		return (*uintValue)(&flag.Value)
+	case *FloatFlag:
+		return (*floatValue)(&flag.Value)
+	case *DurationFlag:
+		return (*durationValue)(&flag.Value)
+	case *TimeFlag:
+		return &timeValue{value: &flag.Value, layout: flag.Layout}
+	case *ValueFlag:
 		return flag.Value
 	default:
+		return nil
+	}
+}
+
+func SetFlagValue(f Flag, str string, boolean bool) {
+	s, ok := f.(statefulFlag)
+	if !ok {
+		return
+	}
+
+	value := flagValue(f)
+	if b, ok := value.(BoolValue); ok && b.IsBool() && str == "" {
+		str = strconv.FormatBool(boolean)
+	}
+
+	state := s.state()
+	if !state.given {
+		state.err = nil
+	}
+
+	state.given = true
+	if err := setValue(value, str, "flag", f.GetName()); err != nil {
+		state.err = err
+	}
+}
+
+func GetFlagStringValue(f any) string {
+	flag, ok := f.(Flag)
+	if !ok {
 		return ""
 	}
+
+	if value := flagValue(flag); value != nil {
+		return value.String()
+	}
+
+	return ""
 }
 
-func GetFlagBoolValue(f any) bool {
+// GetFlagValue returns the value of the flag, typed according to the kind of flag.
+func GetFlagValue(f Flag) InputType {
 	switch flag := f.(type) {
+	case *StringFlag:
+		return flag.Value
 	case *BoolFlag:
 		return flag.Value
+	case *ArrayFlag:
+		return GetFlagArrayValue(flag)
 	case *OptionalStringFlag:
-		return flag.Boolean || flag.Value != ""
+		return flag.Value
 	case *OptionalArrayFlag:
-		return flag.Boolean || len(flag.Value) > 0
+		return GetFlagArrayValue(flag)
+	case *IntFlag:
+		return flag.Value
+	case *UintFlag:
+		return flag.Value
+	case *FloatFlag:
+		return flag.Value
+	case *DurationFlag:
+		return flag.Value
+	case *TimeFlag:
+		return flag.Value
+	case *ValueFlag:
+		return flag.Value
 	default:
+		return nil
+	}
+}
+
+func GetFlagBoolValue(f any) bool {
+	flag, ok := f.(Flag)
+	if !ok {
 		return false
 	}
+
+	return (!FlagAcceptsValue(flag) || FlagValueIsOptional(flag)) && flag.HasValue()
 }
 
 func GetFlagArrayValue(f any) []string {
-	switch flag := f.(type) {
-	case *ArrayFlag:
-		arr := flag.Value
-		if arr == nil {
-			return []string{}
-		}
-		return arr
-	case *OptionalArrayFlag:
-		arr := flag.Value
-		if arr == nil {
-			return []string{}
-		}
-		return arr
-	default:
+	flag, ok := f.(Flag)
+	if !ok {
 		return []string{}
 	}
+
+	if s, ok := flagValue(flag).(SliceValue); ok && s.IsSlice() {
+		return s.GetSlice()
+	}
+
+	return []string{}
 }
 
 func (f *StringFlag) GetName() string {
@@ -169,12 +432,28 @@ func (f *StringFlag) GetDescription() string {
 	return f.Description
 }
 
-func (f *StringFlag) HasValue() bool {
-	return f.Value != ""
+func (f *StringFlag) GetEnvVars() []string {
+	return f.EnvVars
 }
 
-func (f *StringFlag) WasGiven() bool {
-	return f.given
+func (f *StringFlag) GetCompleteFunc() CompletionFunc {
+	return f.CompleteFunc
+}
+
+func (f *StringFlag) IsRequired() bool {
+	return f.Required
+}
+
+func (f *StringFlag) GetDeprecated() string {
+	return f.Deprecated
+}
+
+func (f *StringFlag) GetReplacedBy() string {
+	return f.ReplacedBy
+}
+
+func (f *StringFlag) HasValue() bool {
+	return f.Value != ""
 }
 
 func (f *BoolFlag) GetName() string {
@@ -193,8 +472,20 @@ func (f *BoolFlag) GetDescription() string {
 	return f.Description
 }
 
-func (f *BoolFlag) WasGiven() bool {
-	return f.given
+func (f *BoolFlag) GetEnvVars() []string {
+	return f.EnvVars
+}
+
+func (f *BoolFlag) IsRequired() bool {
+	return f.Required
+}
+
+func (f *BoolFlag) GetDeprecated() string {
+	return f.Deprecated
+}
+
+func (f *BoolFlag) GetReplacedBy() string {
+	return f.ReplacedBy
 }
 
 func (f *BoolFlag) HasValue() bool {
@@ -217,8 +508,24 @@ func (f *ArrayFlag) GetDescription() string {
 	return f.Description
 }
 
-func (f *ArrayFlag) WasGiven() bool {
-	return f.given
+func (f *ArrayFlag) GetEnvVars() []string {
+	return f.EnvVars
+}
+
+func (f *ArrayFlag) GetCompleteFunc() CompletionFunc {
+	return f.CompleteFunc
+}
+
+func (f *ArrayFlag) IsRequired() bool {
+	return f.Required
+}
+
+func (f *ArrayFlag) GetDeprecated() string {
+	return f.Deprecated
+}
+
+func (f *ArrayFlag) GetReplacedBy() string {
+	return f.ReplacedBy
 }
 
 func (f *ArrayFlag) HasValue() bool {
@@ -249,8 +556,24 @@ func (f *OptionalStringFlag) GetDescription() string {
 	return f.Description
 }
 
-func (f *OptionalStringFlag) WasGiven() bool {
-	return f.given
+func (f *OptionalStringFlag) GetEnvVars() []string {
+	return f.EnvVars
+}
+
+func (f *OptionalStringFlag) GetCompleteFunc() CompletionFunc {
+	return f.CompleteFunc
+}
+
+func (f *OptionalStringFlag) IsRequired() bool {
+	return f.Required
+}
+
+func (f *OptionalStringFlag) GetDeprecated() string {
+	return f.Deprecated
+}
+
+func (f *OptionalStringFlag) GetReplacedBy() string {
+	return f.ReplacedBy
 }
 
 func (f *OptionalStringFlag) Opts() []string {
@@ -273,8 +596,24 @@ func (f *OptionalArrayFlag) GetDescription() string {
 	return f.Description
 }
 
-func (f *OptionalArrayFlag) WasGiven() bool {
-	return f.given
+func (f *OptionalArrayFlag) GetEnvVars() []string {
+	return f.EnvVars
+}
+
+func (f *OptionalArrayFlag) GetCompleteFunc() CompletionFunc {
+	return f.CompleteFunc
+}
+
+func (f *OptionalArrayFlag) IsRequired() bool {
+	return f.Required
+}
+
+func (f *OptionalArrayFlag) GetDeprecated() string {
+	return f.Deprecated
+}
+
+func (f *OptionalArrayFlag) GetReplacedBy() string {
+	return f.ReplacedBy
 }
 
 func (f *OptionalArrayFlag) HasValue() bool {
@@ -285,12 +624,218 @@ func (f *OptionalArrayFlag) Opts() []string {
 	return f.Options
 }
 
+func (f *IntFlag) GetName() string {
+	return f.Name
+}
+
+func (f *IntFlag) GetShortcuts() []string {
+	return f.Shortcuts
+}
+
+func (f *IntFlag) GetShortcutString() string {
+	return joinShortcuts(f.Shortcuts)
+}
+
+func (f *IntFlag) GetDescription() string {
+	return f.Description
+}
+
+func (f *IntFlag) GetEnvVars() []string {
+	return f.EnvVars
+}
+
+func (f *IntFlag) GetCompleteFunc() CompletionFunc {
+	return f.CompleteFunc
+}
+
+func (f *IntFlag) IsRequired() bool {
+	return f.Required
+}
+
+func (f *IntFlag) GetDeprecated() string {
+	return f.Deprecated
+}
+
+func (f *IntFlag) GetReplacedBy() string {
+	return f.ReplacedBy
+}
+
+func (f *IntFlag) HasValue() bool {
+	return f.Value != 0
+}
+
+func (f *UintFlag) GetName() string {
+	return f.Name
+}
+
+func (f *UintFlag) GetShortcuts() []string {
+	return f.Shortcuts
+}
+
+func (f *UintFlag) GetShortcutString() string {
+	return joinShortcuts(f.Shortcuts)
+}
+
+func (f *UintFlag) GetDescription() string {
+	return f.Description
+}
+
+func (f *UintFlag) GetEnvVars() []string {
+	return f.EnvVars
+}
+
+func (f *UintFlag) GetCompleteFunc() CompletionFunc {
+	return f.CompleteFunc
+}
+
+func (f *UintFlag) IsRequired() bool {
+	return f.Required
+}
+
+func (f *UintFlag) GetDeprecated() string {
+	return f.Deprecated
+}
+
+func (f *UintFlag) GetReplacedBy() string {
+	return f.ReplacedBy
+}
+
+func (f *UintFlag) HasValue() bool {
+	return f.Value != 0
+}
+
+func (f *FloatFlag) GetName() string {
+	return f.Name
+}
+
+func (f *FloatFlag) GetShortcuts() []string {
+	return f.Shortcuts
+}
+
+func (f *FloatFlag) GetShortcutString() string {
+	return joinShortcuts(f.Shortcuts)
+}
+
+func (f *FloatFlag) GetDescription() string {
+	return f.Description
+}
+
+func (f *FloatFlag) GetEnvVars() []string {
+	return f.EnvVars
+}
+
+func (f *FloatFlag) GetCompleteFunc() CompletionFunc {
+	return f.CompleteFunc
+}
+
+func (f *FloatFlag) IsRequired() bool {
+	return f.Required
+}
+
+func (f *FloatFlag) GetDeprecated() string {
+	return f.Deprecated
+}
+
+func (f *FloatFlag) GetReplacedBy() string {
+	return f.ReplacedBy
+}
+
+func (f *FloatFlag) HasValue() bool {
+	return f.Value != 0
+}
+
+func (f *DurationFlag) GetName() string {
+	return f.Name
+}
+
+func (f *DurationFlag) GetShortcuts() []string {
+	return f.Shortcuts
+}
+
+func (f *DurationFlag) GetShortcutString() string {
+	return joinShortcuts(f.Shortcuts)
+}
+
+func (f *DurationFlag) GetDescription() string {
+	return f.Description
+}
+
+func (f *DurationFlag) GetEnvVars() []string {
+	return f.EnvVars
+}
+
+func (f *DurationFlag) GetCompleteFunc() CompletionFunc {
+	return f.CompleteFunc
+}
+
+func (f *DurationFlag) IsRequired() bool {
+	return f.Required
+}
+
+func (f *DurationFlag) GetDeprecated() string {
+	return f.Deprecated
+}
+
+func (f *DurationFlag) GetReplacedBy() string {
+	return f.ReplacedBy
+}
+
+func (f *DurationFlag) HasValue() bool {
+	return f.Value != 0
+}
+
+func (f *TimeFlag) GetName() string {
+	return f.Name
+}
+
+func (f *TimeFlag) GetShortcuts() []string {
+	return f.Shortcuts
+}
+
+func (f *TimeFlag) GetShortcutString() string {
+	return joinShortcuts(f.Shortcuts)
+}
+
+func (f *TimeFlag) GetDescription() string {
+	return f.Description
+}
+
+func (f *TimeFlag) GetEnvVars() []string {
+	return f.EnvVars
+}
+
+func (f *TimeFlag) GetCompleteFunc() CompletionFunc {
+	return f.CompleteFunc
+}
+
+func (f *TimeFlag) IsRequired() bool {
+	return f.Required
+}
+
+func (f *TimeFlag) GetDeprecated() string {
+	return f.Deprecated
+}
+
+func (f *TimeFlag) GetReplacedBy() string {
+	return f.ReplacedBy
+}
+
+func (f *TimeFlag) HasValue() bool {
+	return !f.Value.IsZero()
+}
+
 const (
 	flagTypeString = iota
 	flagTypeBool
 	flagTypeArray
 	flagTypeOptionalString
 	flagTypeOptionalArray
+	flagTypeInt
+	flagTypeUint
+	flagTypeFloat
+	flagTypeDuration
+	flagTypeTime
+	flagTypeValue
 )
 
 func FlagType(f Flag) uint {
@@ -305,6 +850,18 @@ func FlagType(f Flag) uint {
 		return flagTypeOptionalString
 	case *OptionalArrayFlag:
 		return flagTypeOptionalArray
+	case *IntFlag:
+		return flagTypeInt
+	case *UintFlag:
+		return flagTypeUint
+	case *FloatFlag:
+		return flagTypeFloat
+	case *DurationFlag:
+		return flagTypeDuration
+	case *TimeFlag:
+		return flagTypeTime
+	case *ValueFlag:
+		return flagTypeValue
 	default:
 		return 9999
 	}
@@ -323,75 +880,79 @@ func FlagEquals(f1 Flag, f2 Flag) bool {
 		return false
 	}
 
-	f1Type := FlagType(f1)
-	f2Type := FlagType(f2)
-
-	if f1Type != f2Type {
-		return false
-	}
+	return FlagType(f1) == FlagType(f2) &&
+		GetFlagStringValue(f1) == GetFlagStringValue(f2) &&
+		GetFlagBoolValue(f1) == GetFlagBoolValue(f2)
+}
 
-	switch f1Type {
-	case flagTypeString:
-		return GetFlagStringValue(f1) == GetFlagStringValue(f2)
-	case flagTypeBool:
-		return GetFlagBoolValue(f1) == GetFlagBoolValue(f2)
-	case flagTypeArray:
-		return slices.Equal(GetFlagArrayValue(f1), GetFlagArrayValue(f2))
-	case flagTypeOptionalString:
-		return GetFlagBoolValue(f1) == GetFlagBoolValue(f2) && GetFlagStringValue(f1) == GetFlagStringValue(f2)
-	case flagTypeOptionalArray:
-		return GetFlagBoolValue(f1) == GetFlagBoolValue(f2) && slices.Equal(GetFlagArrayValue(f1), GetFlagArrayValue(f2))
+func FlagIsNegatable(f Flag) bool {
+	switch flag := f.(type) {
+	case *BoolFlag:
+		return flag.Negatable
+	case *ValueFlag:
+		return flag.Negatable && flag.IsBool()
 	default:
 		return false
 	}
 }
 
-func FlagIsNegatable(f Flag) bool {
-	b, ok := f.(*BoolFlag)
-	if !ok {
-		return false
+func FlagIsRequired(f Flag) bool {
+	r, ok := f.(CanBeRequired)
+	return ok && r.IsRequired()
+}
+
+// FlagIsDeprecated reports whether the flag is deprecated, either with a message or
+// by being replaced by another flag.
+func FlagIsDeprecated(f Flag) bool {
+	d, ok := f.(CanBeDeprecated)
+	return ok && (d.GetDeprecated() != "" || d.GetReplacedBy() != "")
+}
+
+// FlagReplacement returns the name of the flag that replaces the given flag, if any.
+func FlagReplacement(f Flag) string {
+	if d, ok := f.(CanBeDeprecated); ok {
+		return d.GetReplacedBy()
 	}
-	return b.Negatable
+
+	return ""
 }
 
 func FlagIsArray(f Flag) bool {
-	t := FlagType(f)
-	return t == flagTypeArray || t == flagTypeOptionalArray
+	s, ok := flagValue(f).(SliceValue)
+	return ok && s.IsSlice()
 }
 
 func FlagAcceptsValue(f Flag) bool {
-	t := FlagType(f)
-	return t == flagTypeString || t == flagTypeArray || t == flagTypeOptionalString || t == flagTypeOptionalArray
+	return FlagRequiresValue(f) || FlagValueIsOptional(f)
 }
 
 func FlagRequiresValue(f Flag) bool {
-	t := FlagType(f)
-	return t == flagTypeString || t == flagTypeArray
+	value := flagValue(f)
+	if value == nil {
+		return false
+	}
+
+	if b, ok := value.(BoolValue); ok && b.IsBool() {
+		return false
+	}
+
+	return !FlagValueIsOptional(f)
 }
 
 func FlagValueIsOptional(f Flag) bool {
-	t := FlagType(f)
-	return t == flagTypeOptionalString || t == flagTypeOptionalArray
+	o, ok := flagValue(f).(OptionalValue)
+	return ok && o.IsOptional()
 }
 
 func FlagHasDefaultValue(f Flag) bool {
-	switch t := f.(type) {
-	case *StringFlag:
-		return !t.WasGiven() && t.Value != ""
-	case *BoolFlag:
-		return !t.WasGiven() && t.Value
-	case *ArrayFlag:
-		return !t.WasGiven() && len(t.Value) > 0
-	case *OptionalStringFlag:
-		return !t.WasGiven() && (t.Boolean || t.Value != "")
-	case *OptionalArrayFlag:
-		return !t.WasGiven() && (t.Boolean || len(t.Value) > 0)
-	default:
-		return false
-	}
+	return !f.WasGiven() && f.HasValue()
 }
 
 func ValidateFlag(f Flag) error {
+	if s, ok := f.(statefulFlag); ok && s.state().err != nil {
+		return s.state().err
+	}
+
 	switch t := f.(type) {
 	case *StringFlag:
 		if len(t.Options) > 0 {
@@ -450,6 +1011,42 @@ func ValidateFlag(f Flag) error {
 			return t.Validator(t.Boolean, t.Value)
 		}
 
+		return nil
+	case *IntFlag:
+		if t.Validator != nil {
+			return t.Validator(t.Value)
+		}
+
+		return nil
+	case *UintFlag:
+		if t.Validator != nil {
+			return t.Validator(t.Value)
+		}
+
+		return nil
+	case *FloatFlag:
+		if t.Validator != nil {
+			return t.Validator(t.Value)
+		}
+
+		return nil
+	case *DurationFlag:
+		if t.Validator != nil {
+			return t.Validator(t.Value)
+		}
+
+		return nil
+	case *TimeFlag:
+		if t.Validator != nil {
+			return t.Validator(t.Value)
+		}
+
+		return nil
+	case *ValueFlag:
+		if t.Validator != nil {
+			return t.Validator(t.Value)
+		}
+
 		return nil
 	default:
 		return errors.New("invalid flag type")
@@ -465,6 +1062,16 @@ func ArgSetValue(arg Arg, token string) {
 			a.Value = make([]string, 0)
 		}
 		a.Value = append(a.Value, token)
+	case *IntArg:
+		a.err = setValue((*intValue)(&a.Value), token, "argument", a.Name)
+	case *UintArg:
+		a.err = setValue((*uintValue)(&a.Value), token, "argument", a.Name)
+	case *FloatArg:
+		a.err = setValue((*floatValue)(&a.Value), token, "argument", a.Name)
+	case *DurationArg:
+		a.err = setValue((*durationValue)(&a.Value), token, "argument", a.Name)
+	case *TimeArg:
+		a.err = setValue(&timeValue{value: &a.Value, layout: a.Layout}, token, "argument", a.Name)
 	default:
 		return
 	}
@@ -0,0 +1,213 @@
+package cli
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/michielnijenhuis/cli/helper"
+	"github.com/michielnijenhuis/cli/helper/array"
+)
+
+func (c *Command) InitDefaultHelpCmd() {
+	if !c.HasSubcommands() {
+		return
+	}
+
+	if _, exists := c.commands["help"]; exists {
+		return
+	}
+
+	helpCmd := &Command{
+		Name:        "help",
+		Description: "Display help for a command",
+		Help: fmt.Sprintf(`The <accent>help</accent> command displays help for a given command:
+
+  <accent>%[1]s help list</accent>
+
+Subcommands are given by their full path:
+
+  <accent>%[1]s help completion zsh</accent>
+
+You can also output the help in other formats by using the <accent>--format</accent> flag:
+
+  <accent>%[1]s help --format=json list</accent>
+
+To display the list of available commands, please use the <accent>list</accent> command.`, c.Root().Name),
+		Arguments: []Arg{
+			&ArrayArg{
+				Name:        "command",
+				Description: "The command name",
+			},
+		},
+		Flags: []Flag{
+			newFormatFlag(),
+		},
+		RunE: func(io *IO) error {
+			command, err := io.Command.parent.lookup(io.Array("command"))
+			if err != nil {
+				return err
+			}
+
+			return command.describe(io.Output, io.String("format"))
+		},
+	}
+
+	c.AddCommand(helpCmd)
+}
+
+func (c *Command) InitDefaultListCmd() {
+	if !c.HasSubcommands() {
+		return
+	}
+
+	if _, exists := c.commands["list"]; exists {
+		return
+	}
+
+	listCmd := &Command{
+		Name:        "list",
+		Description: "List commands",
+		Help: fmt.Sprintf(`The <accent>list</accent> command lists all commands:
+
+  <accent>%[1]s list</accent>
+
+You can also display the commands of a namespace or group:
+
+  <accent>%[1]s list completion</accent>
+
+You can also output the information in other formats by using the <accent>--format</accent> flag:
+
+  <accent>%[1]s list --format=json</accent>
+
+It's also possible to get raw list of commands (useful for embedding command runner):
+
+  <accent>%[1]s list --raw</accent>`, c.Root().Name),
+		Arguments: []Arg{
+			&StringArg{
+				Name:        "namespace",
+				Description: "The namespace name",
+			},
+		},
+		Flags: []Flag{
+			newFormatFlag(),
+			&BoolFlag{
+				Name:        "raw",
+				Description: "To output raw command list",
+			},
+		},
+		RunE: func(io *IO) error {
+			return io.Command.parent.list(io.Output, io.String("namespace"), io.String("format"), io.Bool("raw"))
+		},
+	}
+
+	c.AddCommand(listCmd)
+}
+
+func newFormatFlag() *StringFlag {
+	return &StringFlag{
+		Name:        "format",
+		Description: "The output format",
+		Options:     DescriptorFormats(),
+		Value:       "txt",
+	}
+}
+
+// lookup resolves the subcommand at the given path of command names or aliases.
+func (c *Command) lookup(path []string) (*Command, error) {
+	current := c
+
+	for _, name := range path {
+		current.init()
+		cmd := current.commands[name]
+		if cmd == nil {
+			if idx := slices.IndexFunc(current.rootPlugins(), func(p *Command) bool { return p.Name == name }); idx != -1 {
+				cmd = current.rootPlugins()[idx]
+			}
+		}
+
+		if cmd == nil {
+			alternatives := c.findAlternatives(name, array.SortedKeys(current.commands))
+			return nil, CommandNotFound(fmt.Sprintf("command \"%s\" does not exist", name), alternatives)
+		}
+
+		current = cmd
+	}
+
+	return current, nil
+}
+
+// list describes the subcommands of the command, or those of the given namespace. The
+// namespace is either the path to a subcommand, or the ID of a command group.
+func (c *Command) list(o *Output, namespace string, format string, raw bool) error {
+	command := c
+	var groups []GroupedCommands
+
+	if namespace != "" {
+		cmd, err := c.lookup(strings.Fields(namespace))
+		if err != nil {
+			idx := slices.IndexFunc(c.GroupedSubcommands(), func(g GroupedCommands) bool { return g.Group.ID == namespace })
+			if idx == -1 {
+				return fmt.Errorf("there are no commands defined in the \"%s\" namespace", namespace)
+			}
+
+			groups = c.GroupedSubcommands()[idx : idx+1]
+		} else {
+			command = cmd
+		}
+	}
+
+	if groups == nil {
+		groups = command.GroupedSubcommands()
+	}
+
+	if raw {
+		o.Writelns(rawCommandList(groups, o.Formatter()), OutputRaw)
+		return nil
+	}
+
+	if namespace == "" || command != c {
+		return command.describe(o, format)
+	}
+
+	if format != "txt" {
+		return fmt.Errorf("the commands of group \"%s\" can only be listed in the txt format", namespace)
+	}
+
+	d := TextDescriptor{o}
+	d.describeCommandGroups(groups)
+	d.writeText(Eol)
+
+	return nil
+}
+
+// rawCommandList returns a line with the name and description of every command in the
+// groups and their subcommands.
+func rawCommandList(groups []GroupedCommands, formatter *OutputFormatter) []string {
+	names := make([]string, 0)
+	descriptions := make([]string, 0)
+
+	var collect func(groups []GroupedCommands, prefix string)
+	collect = func(groups []GroupedCommands, prefix string) {
+		for _, group := range groups {
+			for _, cmd := range group.Commands {
+				names = append(names, prefix+cmd.Name)
+				descriptions = append(descriptions, formatter.RemoveDecoration(cmd.Description))
+				collect(cmd.GroupedSubcommands(), prefix+cmd.Name+" ")
+			}
+		}
+	}
+	collect(groups, "")
+
+	width := 0
+	for _, name := range names {
+		width = max(width, helper.Width(name))
+	}
+
+	lines := make([]string, 0, len(names))
+	for i, name := range names {
+		lines = append(lines, strings.TrimSpace(name+strings.Repeat(" ", width-helper.Width(name))+" "+descriptions[i]))
+	}
+
+	return lines
+}
@@ -3,7 +3,6 @@ package helper
 import (
 	"fmt"
 	"io"
-	"strconv"
 	"strings"
 	"unicode/utf8"
 
@@ -108,25 +107,29 @@ func FormatMemory(memory int) string {
 	format := fmt.Sprintf("%%.%df %%s", 1)
 
 	if memory >= 1024*1024*1024 {
-		return fmt.Sprintf(format, memory/1024/1024/1024, "GiB")
+		return fmt.Sprintf(format, float64(memory)/1024/1024/1024, "GiB")
 	}
 
 	if memory >= 1024*1024 {
-		return fmt.Sprintf(format, memory/1024/1024, "MiB")
+		return fmt.Sprintf(format, float64(memory)/1024/1024, "MiB")
 	}
 
 	if memory >= 1024 {
-		return fmt.Sprintf(format, memory/1024, "KiB")
+		return fmt.Sprintf(format, float64(memory)/1024, "KiB")
 	}
 
 	return fmt.Sprintf("%d B", memory)
 }
 
-var timeFormats = [][]string{
-	{"1", "1 sec", "secs"},
-	{"60", "1 min", "mins"},
-	{"3600", "1 hr", "hrs"},
-	{"86400", "1 day", "days"},
+var timeFormats = []struct {
+	seconds  int
+	singular string
+	plural   string
+}{
+	{1, "1 sec", "secs"},
+	{60, "1 min", "mins"},
+	{3600, "1 hr", "hrs"},
+	{86400, "1 day", "days"},
 }
 
 func FormatTime(secs int, precision int) string {
@@ -139,8 +142,7 @@ func FormatTime(secs int, precision int) string {
 	for i, format := range timeFormats {
 		seconds := secs
 		if i+1 < len(timeFormats) {
-			conv, _ := strconv.Atoi(timeFormats[i+1][0])
-			seconds = secs % conv
+			seconds = secs % timeFormats[i+1].seconds
 		}
 
 		delete(times, i-precision)
@@ -149,29 +151,26 @@ func FormatTime(secs int, precision int) string {
 			continue
 		}
 
-		f, _ := strconv.Atoi(format[0])
-		unitCount := seconds / f
+		unitCount := seconds / format.seconds
 
-		var t string
 		if unitCount == 1 {
-			t = format[1]
+			times[i] = format.singular
 		} else {
-			t = fmt.Sprintf("%d %s", unitCount, format[2])
+			times[i] = fmt.Sprintf("%d %s", unitCount, format.plural)
 		}
-		times[i] = t
 
 		if secs == seconds {
-			continue
+			break
 		}
 
 		secs -= seconds
 	}
 
-	values := make([]string, len(times))
-	i := 0
-	for k := range times {
-		values[i] = fmt.Sprint(k)
-		i++
+	values := make([]string, 0, len(times))
+	for i := len(timeFormats) - 1; i >= 0; i-- {
+		if t, ok := times[i]; ok {
+			values = append(values, t)
+		}
 	}
 
 	return strings.Join(values, ", ")
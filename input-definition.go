@@ -20,6 +20,9 @@ type InputDefinition struct {
 	lastOptionalArgument Arg
 	negations            map[string]string
 	shortcuts            map[string]string
+	envVars              map[string][]string
+	configKeys           map[string][]string
+	flagGroups           []FlagGroup
 }
 
 func (d *InputDefinition) SetDefinition(arguments []Arg, flags []Flag) error {
@@ -227,6 +230,94 @@ func (d *InputDefinition) AddFlag(flag Flag) error {
 	return nil
 }
 
+// BindEnvVars binds the given flags to the environment variables they define. If a
+// prefix is given, flags that do not define any environment variables are bound to
+// a variable derived from the prefix and the flag name, e.g. MYAPP_DRY_RUN.
+func (d *InputDefinition) BindEnvVars(flags []Flag, prefix string) {
+	if d.envVars == nil {
+		d.envVars = make(map[string][]string)
+	}
+
+	for _, flag := range flags {
+		var envVars []string
+		if f, ok := flag.(HasEnvVars); ok {
+			envVars = f.GetEnvVars()
+		}
+
+		if len(envVars) == 0 && prefix != "" {
+			envVars = []string{envVarName(prefix, flag.GetName())}
+		}
+
+		if len(envVars) > 0 {
+			d.envVars[flag.GetName()] = envVars
+		}
+	}
+}
+
+// EnvVars returns the environment variables the flag with the given name is bound to.
+func (d *InputDefinition) EnvVars(name string) []string {
+	if d.envVars == nil {
+		return nil
+	}
+
+	return d.envVars[name]
+}
+
+func (d *InputDefinition) SetFlagGroups(groups []FlagGroup) error {
+	for _, group := range groups {
+		for _, name := range group.Flags {
+			if !d.HasFlag(name) {
+				return fmt.Errorf("flag group references unknown flag \"%s\"", name)
+			}
+		}
+	}
+
+	d.flagGroups = groups
+	return nil
+}
+
+func (d *InputDefinition) FlagGroups() []FlagGroup {
+	return d.flagGroups
+}
+
+// FlagIsExcluded reports whether the flag can no longer be used, because another flag
+// of an exclusive group was given.
+func (d *InputDefinition) FlagIsExcluded(name string, given func(name string) bool) bool {
+	for _, group := range d.flagGroups {
+		if group.Excludes(name, given) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// BindConfigKeys binds the flag with the given name to configuration keys. The first
+// key that is set in the configuration is used.
+func (d *InputDefinition) BindConfigKeys(name string, keys []string) {
+	if d.configKeys == nil {
+		d.configKeys = make(map[string][]string)
+	}
+
+	d.configKeys[name] = keys
+}
+
+// ConfigKeys returns the configuration keys the flag with the given name is bound to.
+func (d *InputDefinition) ConfigKeys(name string) []string {
+	if d.configKeys == nil {
+		return nil
+	}
+
+	return d.configKeys[name]
+}
+
+func envVarName(prefix string, name string) string {
+	name = strings.ToUpper(strings.ReplaceAll(name, "-", "_"))
+	prefix = strings.ToUpper(strings.TrimSuffix(prefix, "_"))
+
+	return prefix + "_" + name
+}
+
 func (d *InputDefinition) HasFlag(name string) bool {
 	o, _ := d.Flag(name)
 	return o != nil
@@ -290,6 +381,10 @@ func (d *InputDefinition) Synopsis(short bool) string {
 		elements = append(elements, "[flags]")
 	} else if !short {
 		for _, f := range flags {
+			if FlagIsDeprecated(f) {
+				continue
+			}
+
 			value := ""
 
 			if FlagAcceptsValue(f) {
@@ -1,12 +1,17 @@
 package cli
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"regexp"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/michielnijenhuis/cli/helper"
 	"github.com/michielnijenhuis/cli/terminal"
@@ -16,8 +21,9 @@ type InputParser func(self any) error
 
 type Input struct {
 	definition      *InputDefinition
-	Stream          *os.File
+	Stream          io.Reader
 	flags           map[string]Flag
+	cliFlags        map[string]bool
 	arguments       map[string]Arg
 	interactive     bool
 	givenArguments  []string
@@ -26,6 +32,26 @@ type Input struct {
 	parsed          []string
 	initialSttyMode string
 	Strict          bool
+	ctx             context.Context
+	cancel          context.CancelCauseFunc
+	config          *Config
+	deprecatedFlags []Flag
+	keys            *keyReader
+}
+
+// keyReader reads key presses from a stream in a single goroutine, one at a time when
+// requested. A read cannot be interrupted, so a read that is still pending when a
+// prompt stops waiting for it is delivered to the next prompt.
+type keyReader struct {
+	stream  io.Reader
+	request chan struct{}
+	result  chan keyPress
+	pending bool
+}
+
+type keyPress struct {
+	key string
+	err error
 }
 
 type ErrMissingArguments interface {
@@ -72,6 +98,13 @@ func NewInput(args ...string) *Input {
 	return i
 }
 
+// SetStream sets the stream that prompts read from. The input is interactive if the
+// stream is a terminal.
+func (i *Input) SetStream(stream io.Reader) {
+	i.Stream = stream
+	i.interactive = terminal.IsTerminal(stream)
+}
+
 func (i *Input) SetDefinition(definition *InputDefinition) error {
 	if definition == nil {
 		i.definition = &InputDefinition{}
@@ -90,8 +123,142 @@ func (i *Input) Bind(definition *InputDefinition) error {
 	i.arguments = make(map[string]Arg)
 	i.givenArguments = make([]string, 0)
 	i.flags = make(map[string]Flag)
+	i.cliFlags = make(map[string]bool)
+	i.deprecatedFlags = make([]Flag, 0)
 	i.definition = definition
-	return i.parse(i.tokens, nil)
+
+	for _, flag := range definition.flags {
+		resetFlag(flag)
+	}
+
+	for _, arg := range definition.arguments {
+		resetArg(arg)
+	}
+
+	if err := i.parse(i.tokens, nil); err != nil {
+		return err
+	}
+
+	if err := i.parseEnvVars(); err != nil {
+		return err
+	}
+
+	return i.parseConfig()
+}
+
+// parseEnvVars sets the flags that were not given on the command line from the
+// environment variables they are bound to. Flags that are excluded by a flag given on
+// the command line are skipped, so the command line wins.
+func (i *Input) parseEnvVars() error {
+	for _, flag := range i.definition.flags {
+		name := flag.GetName()
+		if _, given := i.flags[name]; given || i.definition.FlagIsExcluded(name, i.givenOnCommandLine) {
+			continue
+		}
+
+		for _, envVar := range i.definition.EnvVars(name) {
+			value := os.Getenv(envVar)
+			if value == "" {
+				continue
+			}
+
+			var v any = value
+			if FlagIsArray(flag) {
+				arr := make([]any, 0)
+				for _, item := range strings.Split(value, ",") {
+					arr = append(arr, strings.TrimSpace(item))
+				}
+				v = arr
+			}
+
+			source := fmt.Sprintf("environment variable \"%s\"", envVar)
+			if err := i.setFlagFromSource(flag, source, v); err != nil {
+				return err
+			}
+
+			break
+		}
+	}
+
+	return nil
+}
+
+// parseConfig sets the flags that were not given on the command line or through
+// environment variables from the configuration.
+func (i *Input) parseConfig() error {
+	if i.config == nil {
+		return nil
+	}
+
+	for _, flag := range i.definition.flags {
+		name := flag.GetName()
+		if _, given := i.flags[name]; given || i.definition.FlagIsExcluded(name, i.givenOnCommandLine) {
+			continue
+		}
+
+		for _, key := range i.definition.ConfigKeys(name) {
+			value, ok := i.config.Get(key)
+			if !ok {
+				continue
+			}
+
+			source := fmt.Sprintf("config key \"%s\"", key)
+			if err := i.setFlagFromSource(flag, source, value); err != nil {
+				return err
+			}
+
+			break
+		}
+	}
+
+	return nil
+}
+
+func (i *Input) setFlagFromSource(flag Flag, source string, value any) error {
+	flag = i.resolveDeprecated(flag)
+	name := flag.GetName()
+	if _, given := i.flags[name]; given {
+		// the replacement of a deprecated flag was already set
+		return nil
+	}
+
+	if !FlagAcceptsValue(flag) {
+		boolean, ok := value.(bool)
+		if !ok {
+			b, err := strconv.ParseBool(formatConfigValue(value))
+			if err != nil {
+				return fmt.Errorf("invalid value \"%v\" for %s. Expected a boolean", value, source)
+			}
+			boolean = b
+		}
+
+		return i.SetFlag(name, "", boolean)
+	}
+
+	if arr, ok := value.([]any); ok && FlagIsArray(flag) {
+		for _, v := range arr {
+			if err := i.SetFlag(name, formatConfigValue(v), true); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	return i.SetFlag(name, formatConfigValue(value), true)
+}
+
+// Config returns the configuration loaded for the command. It is never nil.
+func (i *Input) Config() *Config {
+	if i.config == nil {
+		i.config = NewConfig()
+	}
+
+	return i.config
+}
+
+func (i *Input) SetConfig(config *Config) {
+	i.config = config
 }
 
 func (i *Input) parse(tokens []string, inspector *InputInspector) error {
@@ -148,6 +315,10 @@ func (i *Input) Validate() error {
 		}
 	}
 
+	if err := i.validateFlagConstraints(); err != nil {
+		return err
+	}
+
 	validationError := i.runFlagValidators()
 	if validationError != nil {
 		return validationError
@@ -156,6 +327,67 @@ func (i *Input) Validate() error {
 	return i.runArgumentValidators()
 }
 
+// Context returns the context of the running command, which is cancelled
+// when the command is interrupted.
+func (i *Input) Context() context.Context {
+	if i.ctx == nil {
+		return context.Background()
+	}
+
+	return i.ctx
+}
+
+func (i *Input) SetContext(ctx context.Context) {
+	i.ctx, i.cancel = context.WithCancelCause(ctx)
+}
+
+// readKey reads the next key press from the stream, or returns the cause of the context
+// being cancelled while waiting for it.
+func (i *Input) readKey() (string, error) {
+	if i.keys == nil || i.keys.stream != i.Stream {
+		i.keys = newKeyReader(i.Stream)
+	}
+
+	if !i.keys.pending {
+		i.keys.pending = true
+		i.keys.request <- struct{}{}
+	}
+
+	ctx := i.Context()
+	select {
+	case r := <-i.keys.result:
+		i.keys.pending = false
+		return r.key, r.err
+	case <-ctx.Done():
+		return "", context.Cause(ctx)
+	}
+}
+
+func newKeyReader(stream io.Reader) *keyReader {
+	r := &keyReader{
+		stream:  stream,
+		request: make(chan struct{}, 1),
+		result:  make(chan keyPress, 1),
+	}
+
+	// the goroutine is left waiting for requests once the input is no longer used
+	go func() {
+		for range r.request {
+			buffer := make([]byte, 3)
+			n, err := r.stream.Read(buffer)
+			r.result <- keyPress{string(buffer[:n]), err}
+		}
+	}()
+
+	return r
+}
+
+func (i *Input) interrupt() {
+	if i.cancel != nil {
+		i.cancel(ErrInterrupted)
+	}
+}
+
 func (i *Input) IsInteractive() bool {
 	return i.interactive
 }
@@ -209,6 +441,61 @@ func (i *Input) Array(name string) ([]string, error) {
 	return nil, fmt.Errorf("the \"%s\" argument or flag does not exist", name)
 }
 
+func (i *Input) Int(name string) (int, error) {
+	return inputValue[int](i, name, "an integer")
+}
+
+func (i *Input) Uint(name string) (uint, error) {
+	return inputValue[uint](i, name, "a positive integer")
+}
+
+func (i *Input) Float(name string) (float64, error) {
+	return inputValue[float64](i, name, "a number")
+}
+
+func (i *Input) Duration(name string) (time.Duration, error) {
+	return inputValue[time.Duration](i, name, "a duration")
+}
+
+func (i *Input) Time(name string) (time.Time, error) {
+	return inputValue[time.Time](i, name, "a time")
+}
+
+// Value returns the Value that the flag is parsed into.
+func (i *Input) Value(name string) (Value, error) {
+	flag, err := i.definition.Flag(name)
+	if err != nil {
+		return nil, err
+	}
+
+	value := flagValue(flag)
+	if value == nil {
+		return nil, fmt.Errorf("the \"%s\" flag has no value", name)
+	}
+
+	return value, nil
+}
+
+func inputValue[T any](i *Input, name string, kind string) (T, error) {
+	var value InputType
+	var zero T
+
+	if arg, _ := i.definition.Argument(name); arg != nil {
+		value = GetArgValue(arg)
+	} else if flag, _ := i.definition.Flag(name); flag != nil {
+		value = GetFlagValue(flag)
+	} else {
+		return zero, fmt.Errorf("the \"%s\" argument or flag does not exist", name)
+	}
+
+	v, ok := value.(T)
+	if !ok {
+		return zero, fmt.Errorf("the \"%s\" argument or flag is not %s", name, kind)
+	}
+
+	return v, nil
+}
+
 func (i *Input) SetArgument(name string, token string) error {
 	arg, err := i.definition.Argument(name)
 
@@ -268,6 +555,41 @@ func (i *Input) runArgumentValidators() error {
 	return nil
 }
 
+func (i *Input) validateFlagConstraints() error {
+	missing := make([]string, 0)
+	for _, flag := range i.definition.flags {
+		if _, given := i.flags[flag.GetName()]; !given && FlagIsRequired(flag) {
+			missing = append(missing, flag.GetName())
+		}
+	}
+
+	if len(missing) == 1 {
+		return fmt.Errorf("the \"--%s\" flag is required", missing[0])
+	} else if len(missing) > 1 {
+		return fmt.Errorf("the flags %s are required", formatFlagNames(missing, "and"))
+	}
+
+	given := func(name string) bool {
+		_, ok := i.flags[name]
+		return ok
+	}
+
+	for _, group := range i.definition.flagGroups {
+		// environment variables and configuration can satisfy a group that requires a
+		// flag, but only the command line can conflict with the other flags of a group
+		validate := i.givenOnCommandLine
+		if group.Kind == FlagGroupAtLeastOne {
+			validate = given
+		}
+
+		if err := group.Validate(validate); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (i *Input) runFlagValidators() error {
 	for _, flag := range i.definition.flags {
 		_, given := i.flags[flag.GetName()]
@@ -557,17 +879,15 @@ func (i *Input) addShortFlag(shortcut string, token string) error {
 func (i *Input) addLongFlag(name string, token string) error {
 	boolean := true
 
-	if !i.definition.HasFlag(name) {
-		if !i.definition.HasNegation(name) {
-			if !i.Strict {
-				return nil
-			}
-
-			return fmt.Errorf("the \"--%s\" flag does not exist", name)
-		}
-
+	if i.definition.HasNegation(name) {
 		name = i.definition.NegationToName(name)
 		boolean = false
+	} else if !i.definition.HasFlag(name) {
+		if !i.Strict {
+			return nil
+		}
+
+		return fmt.Errorf("the \"--%s\" flag does not exist", name)
 	}
 
 	flag, e := i.definition.Flag(name)
@@ -598,12 +918,46 @@ func (i *Input) addLongFlag(name string, token string) error {
 		return fmt.Errorf("the \"--%s\" flag requires a value", name)
 	}
 
+	flag = i.resolveDeprecated(flag)
+	name = flag.GetName()
+
 	i.flags[name] = flag
+	i.cliFlags[name] = true
 	SetFlagValue(flag, token, boolean)
 
 	return nil
 }
 
+// givenOnCommandLine reports whether the flag was given on the command line, rather
+// than set from an environment variable or the configuration.
+func (i *Input) givenOnCommandLine(name string) bool {
+	return i.cliFlags[name]
+}
+
+// resolveDeprecated records the use of a deprecated flag and returns the flag its value
+// is passed on to: the replacement if it has one, so old invocations keep working.
+func (i *Input) resolveDeprecated(flag Flag) Flag {
+	if !FlagIsDeprecated(flag) {
+		return flag
+	}
+
+	if !slices.Contains(i.deprecatedFlags, flag) {
+		i.deprecatedFlags = append(i.deprecatedFlags, flag)
+	}
+
+	if replacement, _ := i.definition.Flag(FlagReplacement(flag)); replacement != nil {
+		return replacement
+	}
+
+	return flag
+}
+
+// DeprecatedFlags returns the deprecated flags that were given on the command line or
+// set through environment variables or the configuration.
+func (i *Input) DeprecatedFlags() []Flag {
+	return i.deprecatedFlags
+}
+
 func (i *Input) FirstArgument() string {
 	args := i.GivenArguments()
 	if len(args) > 0 {
@@ -631,8 +985,10 @@ func (i *Input) HasParameterFlag(value string, onlyParams bool) bool {
 	return false
 }
 
+// ParameterFlag returns the value of the raw flag, e.g. "--config", from the arguments
+// before they are parsed, or the default value if it is not given.
 func (i *Input) ParameterFlag(value string, defaultValue InputType, onlyParams bool) InputType {
-	tokens := make([]string, 0, len(i.Args))
+	tokens := make([]string, len(i.Args))
 	copy(tokens, i.Args)
 
 	for len(tokens) > 0 {
@@ -642,6 +998,10 @@ func (i *Input) ParameterFlag(value string, defaultValue InputType, onlyParams b
 		}
 
 		if token == value {
+			if len(tokens) == 0 {
+				return defaultValue
+			}
+
 			return helper.Shift(&tokens)
 		}
 
@@ -658,7 +1018,7 @@ func (i *Input) ParameterFlag(value string, defaultValue InputType, onlyParams b
 		}
 	}
 
-	return nil
+	return defaultValue
 }
 
 func StringToInputArgs(cmd string) []string {
@@ -702,7 +1062,13 @@ func StringToInputArgs(cmd string) []string {
 	return out
 }
 
+// SetTty sets the mode of the terminal of the input stream with stty. Streams that are
+// not backed by a file, such as fake terminals, are left untouched.
 func (i *Input) SetTty(mode string) (string, error) {
+	if _, ok := i.Stream.(terminal.FileDescriptor); !ok {
+		return "", nil
+	}
+
 	if i.initialSttyMode == "" {
 		c := exec.Command("stty", "-g")
 		c.Stdin = i.Stream
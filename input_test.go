@@ -0,0 +1,163 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestParameterFlagReadsRawArguments(t *testing.T) {
+	tests := []struct {
+		args     []string
+		expected InputType
+	}{
+		{[]string{"deploy", "--config", "app.toml"}, "app.toml"},
+		{[]string{"deploy", "--config=app.toml"}, "app.toml"},
+		{[]string{"deploy"}, "default"},
+		{[]string{"deploy", "--config"}, "default"},
+		{[]string{"deploy", "--", "--config", "app.toml"}, "default"},
+	}
+
+	for _, test := range tests {
+		i := NewInput(test.args...)
+		if value := i.ParameterFlag("--config", "default", true); value != test.expected {
+			t.Errorf("expected %v for %v, got %v", test.expected, test.args, value)
+		}
+	}
+}
+
+func TestFlagGroupsWithConfig(t *testing.T) {
+	exclusive := FlagGroup{Kind: FlagGroupExclusive, Flags: []string{"token", "token-file"}}
+
+	tests := []struct {
+		name    string
+		group   FlagGroup
+		config  map[string]any
+		args    []string
+		ignored string
+		fails   bool
+	}{
+		{
+			name:    "command line wins over exclusive config",
+			group:   exclusive,
+			config:  map[string]any{"token-file": "secret.txt"},
+			args:    []string{"--token=abc"},
+			ignored: "token-file",
+		},
+		{
+			name:  "exclusive flags on the command line",
+			group: exclusive,
+			args:  []string{"--token=abc", "--token-file=secret.txt"},
+			fails: true,
+		},
+		{
+			name:   "config satisfies at least one",
+			group:  FlagGroup{Kind: FlagGroupAtLeastOne, Flags: []string{"token", "token-file"}},
+			config: map[string]any{"token": "abc"},
+		},
+		{
+			name:   "config does not conflict with all or none",
+			group:  FlagGroup{Kind: FlagGroupAllOrNone, Flags: []string{"user", "password"}},
+			config: map[string]any{"user": "admin"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			definition := &InputDefinition{}
+			for _, name := range test.group.Flags {
+				if err := definition.AddFlag(&StringFlag{Name: name}); err != nil {
+					t.Fatal(err)
+				}
+				definition.BindConfigKeys(name, []string{name})
+			}
+
+			if err := definition.SetFlagGroups([]FlagGroup{test.group}); err != nil {
+				t.Fatal(err)
+			}
+
+			c := NewConfig()
+			c.Merge(test.config)
+
+			i := NewInput(append([]string{}, test.args...)...)
+			i.SetConfig(c)
+
+			err := i.Bind(definition)
+			if err == nil {
+				err = i.Validate()
+			}
+
+			if test.fails != (err != nil) {
+				t.Errorf("expected failure %v, got %v", test.fails, err)
+			}
+
+			if test.ignored != "" && i.FlagProvided(test.ignored) {
+				t.Errorf("expected the config value of \"%s\" to be ignored", test.ignored)
+			}
+		})
+	}
+}
+
+func TestDeprecatedFlagsFromSourcesAreReplaced(t *testing.T) {
+	flags := []Flag{
+		&StringFlag{Name: "zone", EnvVars: []string{"APP_ZONE"}, Deprecated: "zones were renamed", ReplacedBy: "region"},
+		&StringFlag{Name: "region"},
+	}
+
+	definition := &InputDefinition{}
+	if err := definition.SetFlags(flags); err != nil {
+		t.Fatal(err)
+	}
+	definition.BindEnvVars(flags, "")
+
+	t.Setenv("APP_ZONE", "eu")
+
+	i := NewInput()
+	if err := i.Bind(definition); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if region, _ := i.String("region"); region != "eu" {
+		t.Errorf("expected the deprecated flag to set the replacement, got %q", region)
+	}
+
+	if deprecated := i.DeprecatedFlags(); len(deprecated) != 1 || deprecated[0].GetName() != "zone" {
+		t.Errorf("expected the deprecated flag to be reported, got %v", deprecated)
+	}
+
+	i = NewInput("--region=us")
+	if err := i.Bind(definition); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if region, _ := i.String("region"); region != "us" {
+		t.Errorf("expected the command line to win over the deprecated flag, got %q", region)
+	}
+}
+
+func TestKeyPendingOnCancellationIsReadByNextPrompt(t *testing.T) {
+	stream, keys := io.Pipe()
+	defer keys.Close()
+
+	i := NewInput()
+	i.SetStream(stream)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	i.SetContext(ctx)
+
+	if _, err := i.readKey(); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected the read to be cancelled, got %v", err)
+	}
+
+	go func() {
+		keys.Write([]byte("a"))
+		keys.Write([]byte("b"))
+	}()
+
+	i.SetContext(context.Background())
+	if key, err := i.readKey(); key != "a" || err != nil {
+		t.Errorf("expected the first key, got %q and %v", key, err)
+	}
+}
@@ -1,11 +1,10 @@
 package cli
 
 import (
+	"context"
 	"fmt"
-	"os"
-	"os/signal"
 	"strings"
-	"syscall"
+	"time"
 )
 
 type IO struct {
@@ -16,15 +15,20 @@ type IO struct {
 	definition *InputDefinition
 }
 
+func (io *IO) Context() context.Context {
+	return io.Input.Context()
+}
+
 func (io *IO) ChildProcess(cmd string) *ChildProcess {
 	return io.Spawn(cmd, "", true)
 }
 
 func (io *IO) Spawn(cmd string, shell string, inherit bool) *ChildProcess {
 	cp := &ChildProcess{
-		Cmd:   cmd,
-		Shell: shell,
-		Pipe:  !inherit,
+		Cmd:     cmd,
+		Shell:   shell,
+		Pipe:    !inherit,
+		Context: io.Context(),
 	}
 
 	if inherit {
@@ -198,6 +202,30 @@ func (io *IO) Spinner(fn func(), message string) {
 	s.Spin(fn)
 }
 
+func (io *IO) Section() *ConsoleSectionOutput {
+	return io.Output.CreateSection()
+}
+
+func (io *IO) MultiProgress() *MultiProgress {
+	return NewMultiProgress(io.Output)
+}
+
+func (io *IO) ProgressBar(max uint) *ProgressBar {
+	return io.Output.CreateProgressBar(max)
+}
+
+func (io *IO) ProgressStart(max uint) {
+	io.Output.ProgressStart(max)
+}
+
+func (io *IO) ProgressAdvance(step uint) {
+	io.Output.ProgressAdvance(step)
+}
+
+func (io *IO) ProgressFinish() {
+	io.Output.ProgressFinish()
+}
+
 func (io *IO) IsQuiet() bool {
 	return io.Output.IsQuiet()
 }
@@ -242,6 +270,58 @@ func (io *IO) Array(name string) []string {
 	return arr
 }
 
+func (io *IO) Int(name string) int {
+	val, err := io.Input.Int(name)
+	if err != nil {
+		return 0
+	}
+	return val
+}
+
+func (io *IO) Uint(name string) uint {
+	val, err := io.Input.Uint(name)
+	if err != nil {
+		return 0
+	}
+	return val
+}
+
+func (io *IO) Float(name string) float64 {
+	val, err := io.Input.Float(name)
+	if err != nil {
+		return 0
+	}
+	return val
+}
+
+func (io *IO) Duration(name string) time.Duration {
+	val, err := io.Input.Duration(name)
+	if err != nil {
+		return 0
+	}
+	return val
+}
+
+func (io *IO) Time(name string) time.Time {
+	val, err := io.Input.Time(name)
+	if err != nil {
+		return time.Time{}
+	}
+	return val
+}
+
+func (io *IO) Value(name string) Value {
+	val, err := io.Input.Value(name)
+	if err != nil {
+		return nil
+	}
+	return val
+}
+
+func (io *IO) Config() *Config {
+	return io.Input.Config()
+}
+
 func (io *IO) Ask(question string, defaultValue string) (string, error) {
 	return io.Output.Ask(question, defaultValue)
 }
@@ -259,8 +339,6 @@ func (io *IO) View() *View {
 }
 
 func (io *IO) WithGracefulExit(fn func(done <-chan bool)) bool {
-	sigs := make(chan os.Signal, 2)
-	signal.Notify(sigs, os.Interrupt, syscall.SIGINT, syscall.SIGTERM)
 	done := make(chan bool)
 
 	go func(d chan bool) {
@@ -268,10 +346,10 @@ func (io *IO) WithGracefulExit(fn func(done <-chan bool)) bool {
 		d <- true
 	}(done)
 
-	go func(s <-chan os.Signal, d chan<- bool) {
-		<-s
+	go func(ctx context.Context, d chan<- bool) {
+		<-ctx.Done()
 		d <- false
-	}(sigs, done)
+	}(io.Context(), done)
 
 	success := <-done
 	return success
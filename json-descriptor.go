@@ -0,0 +1,46 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+type JsonDescriptor struct {
+	Output *Output
+}
+
+func (d *JsonDescriptor) DescribeCommand(command *Command, options *DescriptorOptions) {
+	d.writeData(describeCommand(command, d.Output.Formatter()))
+}
+
+func (d *JsonDescriptor) DescribeInputDefinition(definition *InputDefinition, options *DescriptorOptions) {
+	var globalFlags []Flag
+	if options != nil {
+		globalFlags = options.globalFlags
+	}
+
+	d.writeData(describeDefinition(definition, globalFlags, d.Output.Formatter()))
+}
+
+func (d *JsonDescriptor) DescribeArgument(argument Arg, options *DescriptorOptions) {
+	d.writeData(describeArgument(argument, d.Output.Formatter()))
+}
+
+func (d *JsonDescriptor) DescribeFlag(flag Flag, options *DescriptorOptions) {
+	var envVars []string
+	if options != nil {
+		envVars = options.envVars
+	}
+
+	d.writeData(describeFlag(flag, envVars, d.Output.Formatter()))
+}
+
+func (d *JsonDescriptor) writeData(data any) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "    ")
+	_ = encoder.Encode(data)
+
+	d.Output.Write(buf.String(), false, OutputRaw)
+}
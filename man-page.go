@@ -0,0 +1,291 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/michielnijenhuis/cli/helper/array"
+)
+
+// ManHeader contains the fields of the ".TH" header of generated man pages. Empty
+// fields are given sensible defaults.
+type ManHeader struct {
+	Title   string
+	Section string
+	Date    *time.Time
+	Source  string
+	Manual  string
+}
+
+// GenManTree writes a man page for the command and each of its visible subcommands
+// to the given directory, e.g. "app-deploy.1" for "app deploy".
+func (c *Command) GenManTree(header *ManHeader, dir string) error {
+	if header == nil {
+		header = &ManHeader{}
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	commands := c.All()
+	for _, name := range array.SortedKeys(commands) {
+		cmd := commands[name]
+		if !cmd.IsVisible() {
+			continue
+		}
+
+		if err := cmd.GenManTree(header, dir); err != nil {
+			return err
+		}
+	}
+
+	section := header.Section
+	if section == "" {
+		section = "1"
+	}
+
+	file, err := os.Create(filepath.Join(dir, manPageName(c)+"."+section))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return c.GenMan(header, file)
+}
+
+// GenMan writes the man page of the command in roff format.
+func (c *Command) GenMan(header *ManHeader, w io.Writer) error {
+	if header == nil {
+		header = &ManHeader{}
+	}
+
+	definition, err := c.Definition()
+	if err != nil {
+		return err
+	}
+
+	formatter := &OutputFormatter{}
+
+	h := *header
+	if h.Title == "" {
+		h.Title = strings.ToUpper(manPageName(c))
+	}
+	if h.Section == "" {
+		h.Section = "1"
+	}
+	if h.Date == nil {
+		now := manDate()
+		h.Date = &now
+	}
+	if h.Source == "" {
+		h.Source = formatter.RemoveDecoration(c.Root().version())
+	}
+
+	buf := new(bytes.Buffer)
+
+	fmt.Fprintf(buf, ".TH %s %s %s %s %s\n", roffQuote(h.Title), roffQuote(h.Section), roffQuote(h.Date.Format("Jan 2006")), roffQuote(h.Source), roffQuote(h.Manual))
+	buf.WriteString(".nh\n.ad l\n")
+
+	buf.WriteString(".SH NAME\n")
+	name := manPageName(c)
+	if c.Description != "" {
+		fmt.Fprintf(buf, "%s \\- %s\n", name, roffEscape(formatter.RemoveDecoration(c.Description)))
+	} else {
+		buf.WriteString(name + "\n")
+	}
+
+	buf.WriteString(".SH SYNOPSIS\n")
+	if c.Run != nil || c.RunE != nil || !c.HasSubcommands() {
+		fmt.Fprintf(buf, "\\fB%s\\fP\n.PP\n", roffEscape(c.Synopsis(false)))
+	}
+	if c.HasSubcommands() {
+		fmt.Fprintf(buf, "\\fB%s\\fP [command]\n.PP\n", roffEscape(c.FullName()))
+	}
+
+	description := c.ProcessedHelp()
+	if description == "" {
+		description = c.Description
+	}
+	if description != "" {
+		buf.WriteString(".SH DESCRIPTION\n")
+		buf.WriteString(roffEscape(strings.TrimSpace(formatter.RemoveDecoration(description))) + "\n")
+	}
+
+	if len(definition.arguments) > 0 {
+		buf.WriteString(".SH ARGUMENTS\n")
+		for _, argument := range definition.arguments {
+			fmt.Fprintf(buf, ".TP\n\\fB%s\\fP\n", roffEscape(argument.GetName()))
+			buf.WriteString(roffEscape(formatter.RemoveDecoration(argument.GetDescription())))
+			if argHasDefaultValue(argument) {
+				fmt.Fprintf(buf, " [default: %s]", roffEscape(formatArgValue(argument)))
+			}
+			buf.WriteString("\n")
+		}
+	}
+
+	inherited, err := c.InheritedFlags()
+	if err != nil {
+		return err
+	}
+	flags := make([]Flag, 0, len(definition.flags))
+	globalFlags := make([]Flag, 0, len(inherited))
+	for _, flag := range definition.flags {
+		if FlagIsDeprecated(flag) {
+			continue
+		}
+
+		if slices.Contains(inherited, flag) {
+			globalFlags = append(globalFlags, flag)
+		} else {
+			flags = append(flags, flag)
+		}
+	}
+
+	if len(flags) > 0 {
+		buf.WriteString(".SH OPTIONS\n")
+		writeManFlags(buf, flags, definition, formatter)
+	}
+
+	if len(globalFlags) > 0 {
+		buf.WriteString(".SH OPTIONS INHERITED FROM PARENT COMMANDS\n")
+		writeManFlags(buf, globalFlags, definition, formatter)
+	}
+
+	seeAlso := make([]string, 0)
+	if c.parent != nil {
+		seeAlso = append(seeAlso, fmt.Sprintf("\\fB%s(%s)\\fP", manPageName(c.parent), h.Section))
+	}
+
+	commands := c.All()
+	for _, name := range array.SortedKeys(commands) {
+		if cmd := commands[name]; cmd.IsVisible() {
+			seeAlso = append(seeAlso, fmt.Sprintf("\\fB%s(%s)\\fP", manPageName(cmd), h.Section))
+		}
+	}
+
+	if len(seeAlso) > 0 {
+		buf.WriteString(".SH SEE ALSO\n")
+		buf.WriteString(strings.Join(seeAlso, ", ") + "\n")
+	}
+
+	_, err = buf.WriteTo(w)
+	return err
+}
+
+func writeManFlags(buf *bytes.Buffer, flags []Flag, definition *InputDefinition, formatter *OutputFormatter) {
+	for _, flag := range flags {
+		names := make([]string, 0, len(flag.GetShortcuts())+2)
+		for _, shortcut := range flag.GetShortcuts() {
+			names = append(names, fmt.Sprintf("\\fB\\-%s\\fP", roffEscape(shortcut)))
+		}
+
+		names = append(names, fmt.Sprintf("\\fB\\-\\-%s\\fP", roffEscape(flag.GetName())))
+		if FlagIsNegatable(flag) {
+			names = append(names, fmt.Sprintf("\\fB\\-\\-no\\-%s\\fP", roffEscape(flag.GetName())))
+		}
+
+		value := ""
+		if FlagAcceptsValue(flag) {
+			value = fmt.Sprintf("=\\fI%s\\fP", roffEscape(strings.ToUpper(flag.GetName())))
+			if FlagValueIsOptional(flag) {
+				value = "[" + value + "]"
+			}
+		}
+
+		description := []string{formatter.RemoveDecoration(flag.GetDescription())}
+		if FlagIsRequired(flag) {
+			description = append(description, "[required]")
+		} else if FlagHasDefaultValue(flag) {
+			description = append(description, fmt.Sprintf("[default: %s]", formatFlagValue(flag)))
+		}
+
+		if envVars := definition.EnvVars(flag.GetName()); len(envVars) > 0 {
+			description = append(description, fmt.Sprintf("[env: %s]", strings.Join(envVars, ", ")))
+		}
+
+		fmt.Fprintf(buf, ".TP\n%s%s\n", strings.Join(names, ", "), value)
+		if text := strings.TrimSpace(strings.Join(description, " ")); text != "" {
+			buf.WriteString(roffEscape(text) + "\n")
+		}
+	}
+}
+
+// InitDefaultGenCmd adds a hidden "gen man <dir>" command that writes the man pages
+// of the whole command tree to the given directory.
+func (c *Command) InitDefaultGenCmd(header *ManHeader) {
+	if _, exists := c.All()["gen"]; exists {
+		return
+	}
+
+	genCmd := &Command{
+		Name:        "gen",
+		Description: "Generate documentation",
+		Hidden:      true,
+	}
+
+	c.AddCommand(genCmd)
+
+	man := &Command{
+		Name:        "man",
+		Description: "Generate man pages for all commands",
+		Arguments: []Arg{
+			&StringArg{
+				Name:        "dir",
+				Description: "The directory to write the man pages to",
+				Value:       ".",
+			},
+		},
+		RunE: func(io *IO) error {
+			return io.Command.Root().GenManTree(header, io.String("dir"))
+		},
+	}
+
+	genCmd.AddCommand(man)
+}
+
+func manPageName(c *Command) string {
+	return strings.ReplaceAll(c.FullName(), " ", "-")
+}
+
+// manDate returns the date of the man pages, which is taken from SOURCE_DATE_EPOCH
+// when set to allow for reproducible builds.
+func manDate() time.Time {
+	if epoch := os.Getenv("SOURCE_DATE_EPOCH"); epoch != "" {
+		if seconds, err := strconv.ParseInt(epoch, 10, 64); err == nil {
+			return time.Unix(seconds, 0).UTC()
+		}
+	}
+
+	return time.Now()
+}
+
+func roffEscape(s string) string {
+	s = strings.ReplaceAll(s, "\\", "\\e")
+	s = strings.ReplaceAll(s, "-", "\\-")
+
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		if strings.HasPrefix(line, ".") || strings.HasPrefix(line, "'") {
+			lines[i] = "\\&" + line
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// roffQuote escapes the string for use as a quoted argument of a macro, such as the
+// fields of the ".TH" header.
+func roffQuote(s string) string {
+	s = strings.ReplaceAll(s, "\n", " ")
+	s = strings.ReplaceAll(roffEscape(s), `"`, `\(dq`)
+
+	return `"` + s + `"`
+}
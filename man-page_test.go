@@ -0,0 +1,37 @@
+package cli_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/michielnijenhuis/cli"
+	"github.com/michielnijenhuis/cli/clitest"
+)
+
+func TestGenMan(t *testing.T) {
+	app := &cli.Command{
+		Name:        "deploy",
+		Description: "Deploy the application",
+		Version:     `1.2 "beta"`,
+		Help:        "The <accent>%command.name%</accent> command deploys the application:\n\n  %command.name% production",
+		Arguments: []cli.Arg{
+			&cli.StringArg{Name: "target", Description: "The target environment", Value: "staging"},
+		},
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: "region", Shortcuts: []string{"r"}, Description: "The region", Value: "eu-west"},
+			&cli.BoolFlag{Name: "force", Description: "Skip the confirmation"},
+		},
+		NativeFlags: []string{"help"},
+		Run:         func(io *cli.IO) {},
+	}
+
+	date := time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC)
+
+	var buf bytes.Buffer
+	if err := app.GenMan(&cli.ManHeader{Date: &date, Manual: `Deploy "Manual"`}, &buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	clitest.AssertGolden(t, "man-deploy", buf.String())
+}
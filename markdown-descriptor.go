@@ -0,0 +1,210 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+type MarkdownDescriptor struct {
+	Output *Output
+}
+
+func (d *MarkdownDescriptor) DescribeCommand(command *Command, options *DescriptorOptions) {
+	d.write(d.commandText(describeCommand(command, d.Output.Formatter())))
+}
+
+func (d *MarkdownDescriptor) DescribeInputDefinition(definition *InputDefinition, options *DescriptorOptions) {
+	var globalFlags []Flag
+	if options != nil {
+		globalFlags = options.globalFlags
+	}
+
+	d.write(d.definitionText(describeDefinition(definition, globalFlags, d.Output.Formatter())))
+}
+
+func (d *MarkdownDescriptor) DescribeArgument(argument Arg, options *DescriptorOptions) {
+	d.write(d.argumentText(describeArgument(argument, d.Output.Formatter())))
+}
+
+func (d *MarkdownDescriptor) DescribeFlag(flag Flag, options *DescriptorOptions) {
+	var envVars []string
+	if options != nil {
+		envVars = options.envVars
+	}
+
+	d.write(d.flagText(describeFlag(flag, envVars, d.Output.Formatter())))
+}
+
+func (d *MarkdownDescriptor) write(content string) {
+	d.Output.Write(content, true, OutputRaw)
+}
+
+func (d *MarkdownDescriptor) commandText(command commandDescription) string {
+	title := "`" + command.FullName + "`"
+	sections := []string{title + Eol + strings.Repeat("-", len(title))}
+
+	if command.Deprecated != "" {
+		sections = append(sections, "**Deprecated:** "+command.Deprecated)
+	}
+
+	if command.Description != "" {
+		sections = append(sections, command.Description)
+	}
+
+	usage := "### Usage" + Eol
+	for _, u := range command.Usage {
+		usage += Eol + "* `" + u + "`"
+	}
+	ns := strings.TrimSuffix(command.FullName, command.Name)
+	for _, alias := range command.Aliases {
+		usage += Eol + "* `" + ns + alias + "`"
+	}
+	sections = append(sections, usage)
+
+	if command.Help != "" && command.Help != command.Description {
+		sections = append(sections, command.Help)
+	}
+
+	if definition := d.definitionText(command.Definition); definition != "" {
+		sections = append(sections, definition)
+	}
+
+	for _, cmd := range command.Commands {
+		sections = append(sections, d.commandText(cmd))
+	}
+
+	return strings.Join(sections, Eol+Eol)
+}
+
+func (d *MarkdownDescriptor) definitionText(definition definitionDescription) string {
+	sections := make([]string, 0)
+
+	if len(definition.Arguments) > 0 {
+		sections = append(sections, "### Arguments")
+		for _, argument := range definition.Arguments {
+			sections = append(sections, d.argumentText(argument))
+		}
+	}
+
+	flags := make([]flagDescription, 0, len(definition.Flags))
+	globalFlags := make([]flagDescription, 0, len(definition.Flags))
+	for _, flag := range definition.Flags {
+		if flag.IsGlobal {
+			globalFlags = append(globalFlags, flag)
+		} else {
+			flags = append(flags, flag)
+		}
+	}
+
+	if len(flags) > 0 {
+		sections = append(sections, "### Flags")
+		for _, flag := range flags {
+			sections = append(sections, d.flagText(flag))
+		}
+	}
+
+	if len(globalFlags) > 0 {
+		sections = append(sections, "### Global flags")
+		for _, flag := range globalFlags {
+			sections = append(sections, d.flagText(flag))
+		}
+	}
+
+	if len(definition.FlagGroups) > 0 {
+		groups := "### Flag groups" + Eol
+		for _, group := range definition.FlagGroups {
+			names := make([]string, 0, len(group.Flags))
+			for _, name := range group.Flags {
+				names = append(names, "`--"+name+"`")
+			}
+
+			groups += Eol + fmt.Sprintf("* %s: %s", group.Description, strings.Join(names, ", "))
+		}
+		sections = append(sections, groups)
+	}
+
+	return strings.Join(sections, Eol+Eol)
+}
+
+func (d *MarkdownDescriptor) argumentText(argument argumentDescription) string {
+	lines := []string{"#### `" + argument.Name + "`", ""}
+
+	if argument.Description != "" {
+		lines = append(lines, argument.Description, "")
+	}
+
+	lines = append(lines,
+		"* Is required: "+markdownBool(argument.IsRequired),
+		"* Is array: "+markdownBool(argument.IsArray),
+	)
+
+	if len(argument.Options) > 0 {
+		lines = append(lines, "* Options: `"+strings.Join(argument.Options, "`, `")+"`")
+	}
+
+	if argument.Default != nil {
+		lines = append(lines, "* Default: "+markdownValue(argument.Default))
+	}
+
+	return strings.Join(lines, Eol)
+}
+
+func (d *MarkdownDescriptor) flagText(flag flagDescription) string {
+	name := flag.Name
+	if flag.Shortcut != "" {
+		name += "|" + flag.Shortcut
+	}
+
+	lines := []string{"#### `" + name + "`", ""}
+
+	if flag.Description != "" {
+		lines = append(lines, flag.Description, "")
+	}
+
+	if flag.Deprecated != "" || flag.ReplacedBy != "" {
+		deprecated := "**Deprecated**"
+		if flag.Deprecated != "" {
+			deprecated += ": " + flag.Deprecated
+		}
+		if flag.ReplacedBy != "" {
+			deprecated += fmt.Sprintf(" (use `--%s` instead)", flag.ReplacedBy)
+		}
+		lines = append(lines, deprecated, "")
+	}
+
+	lines = append(lines,
+		"* Accept value: "+markdownBool(flag.AcceptValue),
+		"* Is value required: "+markdownBool(flag.IsValueRequired),
+		"* Is multiple: "+markdownBool(flag.IsMultiple),
+		"* Is negatable: "+markdownBool(flag.IsNegatable),
+		"* Is required: "+markdownBool(flag.IsRequired),
+	)
+
+	if len(flag.Options) > 0 {
+		lines = append(lines, "* Options: `"+strings.Join(flag.Options, "`, `")+"`")
+	}
+
+	if flag.Default != nil {
+		lines = append(lines, "* Default: "+markdownValue(flag.Default))
+	}
+
+	if len(flag.EnvVars) > 0 {
+		lines = append(lines, "* Environment variables: `"+strings.Join(flag.EnvVars, "`, `")+"`")
+	}
+
+	return strings.Join(lines, Eol)
+}
+
+func markdownBool(b bool) string {
+	if b {
+		return "yes"
+	}
+
+	return "no"
+}
+
+func markdownValue(value any) string {
+	content, _ := json.Marshal(value)
+	return "`" + string(content) + "`"
+}
@@ -0,0 +1,171 @@
+package cli
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// MultiProgress keeps multiple progress bars and spinners stacked on screen, each
+// rendered in its own console section. Bars and spinners may be updated from
+// different goroutines.
+type MultiProgress struct {
+	Interval time.Duration
+	output   *Output
+	bars     []*ProgressBar
+	spinners []*SectionSpinner
+	stop     chan struct{}
+	wg       sync.WaitGroup
+	mu       sync.Mutex
+}
+
+type SectionSpinner struct {
+	Frames   []string
+	Color    string
+	section  *ConsoleSectionOutput
+	message  string
+	count    int
+	finished bool
+	mu       sync.Mutex
+}
+
+func NewMultiProgress(o *Output) *MultiProgress {
+	return &MultiProgress{
+		Interval: 100 * time.Millisecond,
+		output:   o,
+		bars:     make([]*ProgressBar, 0),
+		spinners: make([]*SectionSpinner, 0),
+	}
+}
+
+func (m *MultiProgress) AddBar(max uint) *ProgressBar {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	section := m.output.CreateSection()
+	bar := section.Output.CreateProgressBar(max)
+	bar.Start(-1, 0)
+
+	m.bars = append(m.bars, bar)
+
+	return bar
+}
+
+func (m *MultiProgress) AddSpinner(message string) *SectionSpinner {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	s := &SectionSpinner{
+		Frames:  DotSpinner,
+		Color:   ColorCyan,
+		section: m.output.CreateSection(),
+		message: message,
+	}
+
+	s.render()
+	m.spinners = append(m.spinners, s)
+
+	if m.stop == nil {
+		m.stop = make(chan struct{})
+		m.wg.Add(1)
+		go m.animate(m.stop)
+	}
+
+	return s
+}
+
+func (m *MultiProgress) animate(stop <-chan struct{}) {
+	defer m.wg.Done()
+
+	ticker := time.NewTicker(m.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			m.mu.Lock()
+			spinners := make([]*SectionSpinner, len(m.spinners))
+			copy(spinners, m.spinners)
+			m.mu.Unlock()
+
+			for _, s := range spinners {
+				s.tick()
+			}
+		}
+	}
+}
+
+// Finish finishes all bars and spinners that are still running, and stops the animation.
+func (m *MultiProgress) Finish() {
+	m.mu.Lock()
+	stop := m.stop
+	m.stop = nil
+	bars := m.bars
+	spinners := m.spinners
+	m.mu.Unlock()
+
+	if stop != nil {
+		close(stop)
+		m.wg.Wait()
+	}
+
+	for _, bar := range bars {
+		bar.Finish()
+	}
+
+	for _, s := range spinners {
+		s.Finish("")
+	}
+}
+
+func (s *SectionSpinner) SetMessage(message string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.message = message
+	s.render()
+}
+
+// Finish stops the spinner and replaces it with a tick, followed by the given message
+// or the current message if none is given.
+func (s *SectionSpinner) Finish(message string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.finished {
+		return
+	}
+
+	s.finished = true
+	if message != "" {
+		s.message = message
+	}
+
+	s.section.Overwrite(fmt.Sprintf("<fg=green>%s</> %s", IconTickSwoosh, s.message))
+}
+
+func (s *SectionSpinner) IsFinished() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.finished
+}
+
+func (s *SectionSpinner) tick() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.finished || !s.section.IsDecorated() {
+		return
+	}
+
+	s.count++
+	s.render()
+}
+
+func (s *SectionSpinner) render() {
+	frame := s.Frames[s.count%len(s.Frames)]
+	s.section.Overwrite(fmt.Sprintf("<fg=%s>%s</> %s", s.Color, frame, s.message))
+}
@@ -7,7 +7,6 @@ import (
 
 	"github.com/michielnijenhuis/cli/helper/array"
 	"github.com/michielnijenhuis/cli/helper/keys"
-	"github.com/michielnijenhuis/cli/terminal"
 )
 
 // []string or map[string]string
@@ -165,8 +164,8 @@ func (p *MultiSelectPrompt) IsSelected(value string) bool {
 }
 
 func (p *MultiSelectPrompt) View() string {
-	renderer := NewRenderer()
-	terminalWidth := terminal.Columns()
+	renderer := newRenderer(p.output.Width())
+	terminalWidth := p.output.Width()
 	maxWidth := terminalWidth - 6
 	state := p.State
 	label := Truncate(p.Label, maxWidth, "")
@@ -203,7 +202,7 @@ func (p *MultiSelectPrompt) Render() ([]string, error) {
 func (p *MultiSelectPrompt) renderOptions() string {
 	visible := p.Visible()
 	items := make([]string, 0, len(visible))
-	terminalWidth := terminal.Columns()
+	terminalWidth := p.output.Width()
 
 	for _, label := range visible {
 		idx := array.IndexOf(p.labels, label)
@@ -0,0 +1,293 @@
+package cli
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// optionField links a field of a Command's Options struct to the flag or argument
+// created for it.
+type optionField struct {
+	value      reflect.Value
+	flag       Flag
+	arg        Arg
+	persistent bool
+}
+
+var (
+	durationType = reflect.TypeOf(time.Duration(0))
+	timeType     = reflect.TypeOf(time.Time{})
+	valueType    = reflect.TypeOf((*Value)(nil)).Elem()
+)
+
+// buildOptions turns the fields of the Options struct into flags and arguments of the
+// command. Fields are configured through struct tags:
+//
+//	cli:"name,shortcut=n,arg,required,negatable,persistent"
+//	desc:"The description"
+//	env:"NAME,OTHER_NAME"
+//
+// The name defaults to the kebab-cased field name, a name of "-" skips the field.
+// Nested structs group their fields, prefixing their names with the name of the
+// struct field, unless the struct is embedded.
+func (c *Command) buildOptions() error {
+	if c.Options == nil || c.optionFields != nil {
+		return nil
+	}
+
+	v := reflect.ValueOf(c.Options)
+	if v.Kind() != reflect.Pointer || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("options of command \"%s\" must be a pointer to a struct", c.Name)
+	}
+
+	fields, err := buildOptionFields(v.Elem(), "")
+	if err != nil {
+		return fmt.Errorf("options of command \"%s\": %s", c.Name, err)
+	}
+
+	for _, of := range fields {
+		switch {
+		case of.arg != nil:
+			c.Arguments = append(c.Arguments, of.arg)
+		case of.persistent:
+			c.PersistentFlags = append(c.PersistentFlags, of.flag)
+		default:
+			c.Flags = append(c.Flags, of.flag)
+		}
+	}
+
+	c.optionFields = fields
+	return nil
+}
+
+func buildOptionFields(v reflect.Value, prefix string) ([]optionField, error) {
+	t := v.Type()
+	fields := make([]optionField, 0, t.NumField())
+
+	for idx := 0; idx < t.NumField(); idx++ {
+		field := t.Field(idx)
+		if !field.IsExported() {
+			continue
+		}
+
+		tag := field.Tag.Get("cli")
+		if tag == "-" {
+			continue
+		}
+
+		segments := strings.Split(tag, ",")
+		name := strings.TrimSpace(segments[0])
+		if name == "" {
+			name = kebabCase(field.Name)
+		}
+
+		fieldValue := v.Field(idx)
+
+		if field.Type.Kind() == reflect.Struct && field.Type != timeType && !reflect.PointerTo(field.Type).Implements(valueType) {
+			nestedPrefix := prefix
+			if !field.Anonymous {
+				nestedPrefix = prefix + name + "-"
+			}
+
+			nested, err := buildOptionFields(fieldValue, nestedPrefix)
+			if err != nil {
+				return nil, err
+			}
+
+			fields = append(fields, nested...)
+			continue
+		}
+
+		name = prefix + name
+
+		var shortcuts []string
+		var options []string
+		var isArg, required, negatable, persistent bool
+
+		for _, segment := range segments[1:] {
+			key, value, _ := strings.Cut(strings.TrimSpace(segment), "=")
+			switch key {
+			case "shortcut":
+				shortcuts = strings.Split(value, "|")
+			case "options":
+				options = strings.Split(value, "|")
+			case "arg":
+				isArg = true
+			case "required":
+				required = true
+			case "negatable":
+				negatable = true
+			case "persistent":
+				persistent = true
+			default:
+				return nil, fmt.Errorf("unknown option \"%s\" in tag of field \"%s\"", key, field.Name)
+			}
+		}
+
+		desc := field.Tag.Get("desc")
+
+		var envVars []string
+		if env := field.Tag.Get("env"); env != "" {
+			envVars = strings.Split(env, ",")
+		}
+
+		of := optionField{value: fieldValue, persistent: persistent}
+
+		if isArg {
+			arg, err := optionArg(fieldValue, name, desc, required, options)
+			if err != nil {
+				return nil, fmt.Errorf("field \"%s\": %s", field.Name, err)
+			}
+
+			of.arg = arg
+		} else {
+			flag, err := optionFlag(fieldValue, name, desc, shortcuts, envVars, required, negatable, options)
+			if err != nil {
+				return nil, fmt.Errorf("field \"%s\": %s", field.Name, err)
+			}
+
+			of.flag = flag
+		}
+
+		fields = append(fields, of)
+	}
+
+	return fields, nil
+}
+
+func optionFlag(v reflect.Value, name string, desc string, shortcuts []string, envVars []string, required bool, negatable bool, options []string) (Flag, error) {
+	if v.CanAddr() && v.Addr().Type().Implements(valueType) {
+		return &ValueFlag{Name: name, Shortcuts: shortcuts, Description: desc, EnvVars: envVars, Required: required, Negatable: negatable, Value: v.Addr().Interface().(Value)}, nil
+	}
+
+	switch v.Type() {
+	case durationType:
+		return &DurationFlag{Name: name, Shortcuts: shortcuts, Description: desc, EnvVars: envVars, Required: required, Value: time.Duration(v.Int())}, nil
+	case timeType:
+		return &TimeFlag{Name: name, Shortcuts: shortcuts, Description: desc, EnvVars: envVars, Required: required, Value: v.Interface().(time.Time)}, nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return &StringFlag{Name: name, Shortcuts: shortcuts, Description: desc, EnvVars: envVars, Required: required, Options: options, Value: v.String()}, nil
+	case reflect.Bool:
+		return &BoolFlag{Name: name, Shortcuts: shortcuts, Description: desc, EnvVars: envVars, Required: required, Negatable: negatable, Value: v.Bool()}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return &IntFlag{Name: name, Shortcuts: shortcuts, Description: desc, EnvVars: envVars, Required: required, Value: int(v.Int())}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &UintFlag{Name: name, Shortcuts: shortcuts, Description: desc, EnvVars: envVars, Required: required, Value: uint(v.Uint())}, nil
+	case reflect.Float32, reflect.Float64:
+		return &FloatFlag{Name: name, Shortcuts: shortcuts, Description: desc, EnvVars: envVars, Required: required, Value: v.Float()}, nil
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.String {
+			return &ArrayFlag{Name: name, Shortcuts: shortcuts, Description: desc, EnvVars: envVars, Required: required, Options: options, Value: stringSlice(v)}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("unsupported flag type \"%s\"", v.Type())
+}
+
+func optionArg(v reflect.Value, name string, desc string, required bool, options []string) (Arg, error) {
+	switch v.Type() {
+	case durationType:
+		return &DurationArg{Name: name, Description: desc, Required: required, Value: time.Duration(v.Int())}, nil
+	case timeType:
+		return &TimeArg{Name: name, Description: desc, Required: required, Value: v.Interface().(time.Time)}, nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return &StringArg{Name: name, Description: desc, Required: required, Options: options, Value: v.String()}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return &IntArg{Name: name, Description: desc, Required: required, Value: int(v.Int())}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &UintArg{Name: name, Description: desc, Required: required, Value: uint(v.Uint())}, nil
+	case reflect.Float32, reflect.Float64:
+		return &FloatArg{Name: name, Description: desc, Required: required, Value: v.Float()}, nil
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.String {
+			var minimum uint
+			if required {
+				minimum = 1
+			}
+
+			return &ArrayArg{Name: name, Description: desc, Min: minimum, Options: options, Value: stringSlice(v)}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("unsupported argument type \"%s\"", v.Type())
+}
+
+func stringSlice(v reflect.Value) []string {
+	arr := make([]string, 0, v.Len())
+	for i := 0; i < v.Len(); i++ {
+		arr = append(arr, v.Index(i).String())
+	}
+
+	return arr
+}
+
+// populateOptions copies the values of the flags and arguments back into the fields
+// of the Options struct.
+func (c *Command) populateOptions() {
+	for _, of := range c.optionFields {
+		var value InputType
+		if of.flag != nil {
+			value = GetFlagValue(of.flag)
+		} else {
+			value = GetArgValue(of.arg)
+		}
+
+		// value flags write to the field directly
+		if _, ok := value.(Value); ok {
+			continue
+		}
+
+		rv := reflect.ValueOf(value)
+		switch of.value.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if d, ok := value.(time.Duration); ok {
+				of.value.SetInt(int64(d))
+			} else {
+				of.value.SetInt(rv.Int())
+			}
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			of.value.SetUint(rv.Uint())
+		case reflect.Float32, reflect.Float64:
+			of.value.SetFloat(rv.Float())
+		case reflect.Slice:
+			arr := value.([]string)
+			slice := reflect.MakeSlice(of.value.Type(), len(arr), len(arr))
+			for i, s := range arr {
+				slice.Index(i).SetString(s)
+			}
+			of.value.Set(slice)
+		default:
+			of.value.Set(rv.Convert(of.value.Type()))
+		}
+	}
+}
+
+func kebabCase(name string) string {
+	var b strings.Builder
+	runes := []rune(name)
+
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			lowerBefore := i > 0 && !unicode.IsUpper(runes[i-1])
+			lowerAfter := i > 0 && i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if lowerBefore || lowerAfter {
+				b.WriteRune('-')
+			}
+
+			r = unicode.ToLower(r)
+		}
+
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
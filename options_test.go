@@ -0,0 +1,90 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+type invalidOptions struct {
+	Region string
+	Labels map[string]string
+}
+
+func TestInvalidOptionsAreReported(t *testing.T) {
+	c := &Command{Name: "deploy", Options: &invalidOptions{}}
+
+	for range 2 {
+		if _, err := c.Definition(); err == nil || !strings.Contains(err.Error(), "unsupported flag type") {
+			t.Errorf("expected an unsupported flag type error, got %v", err)
+		}
+	}
+
+	if len(c.Flags) > 0 {
+		t.Errorf("expected no flags to be added for invalid options, got %d", len(c.Flags))
+	}
+}
+
+func TestInheritedFlagsReportInvalidParentOptions(t *testing.T) {
+	app := &Command{Name: "app", Options: &invalidOptions{}}
+	deploy := &Command{Name: "deploy"}
+	deploy.SetParent(app)
+
+	if _, err := deploy.InheritedFlags(); err == nil || !strings.Contains(err.Error(), "unsupported flag type") {
+		t.Errorf("expected an unsupported flag type error, got %v", err)
+	}
+}
+
+func TestAddCommandValidatesOptions(t *testing.T) {
+	app := &Command{Name: "app"}
+
+	err := app.AddCommand(&Command{Name: "deploy", Options: &invalidOptions{}, Run: func(io *IO) {}})
+	if err == nil {
+		t.Errorf("expected adding a command with invalid options to fail")
+	}
+}
+
+func TestExecuteReportsInvalidOptions(t *testing.T) {
+	var out bytes.Buffer
+	app := &Command{
+		Name: "app",
+		Commands: []*Command{
+			{Name: "deploy", Options: &invalidOptions{}, Run: func(io *IO) {}},
+			{Name: "status", Run: func(io *IO) {}},
+		},
+	}
+	app.SetOut(&out)
+	app.SetErr(&out)
+
+	code, err := app.Execute("deploy", "--region=eu")
+	if err == nil || code != ExitFailure {
+		t.Errorf("expected the invalid options to fail with exit code %d, got %d and %v", ExitFailure, code, err)
+	}
+}
+
+type deployOptions struct {
+	Region  string   `cli:"region,shortcut=r" desc:"The region"`
+	Tags    []string `cli:"tag"`
+	Verbose bool     `cli:",persistent"`
+	Target  string   `cli:",arg,required"`
+}
+
+func TestOptionsArePopulated(t *testing.T) {
+	options := &deployOptions{Region: "eu"}
+	var ran bool
+	app := &Command{
+		Name:    "deploy",
+		Options: options,
+		Run: func(io *IO) {
+			ran = true
+		},
+	}
+
+	if _, err := app.Execute("production", "-r", "us", "--tag=a", "--tag=b"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !ran || options.Target != "production" || options.Region != "us" || strings.Join(options.Tags, ",") != "a,b" {
+		t.Errorf("unexpected options %+v", options)
+	}
+}
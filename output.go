@@ -3,10 +3,13 @@ package cli
 import (
 	"errors"
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"regexp"
+	"runtime"
 	"strings"
+	"sync"
 
 	"github.com/michielnijenhuis/cli/helper"
 	"github.com/michielnijenhuis/cli/terminal"
@@ -14,17 +17,28 @@ import (
 
 const maxLineLength = 120
 
+// The size that is assumed for output that is not written to a terminal.
+const (
+	defaultWidth  = 80
+	defaultHeight = 24
+)
+
 type Output struct {
-	Stream         *os.File
+	Stream         io.Writer
 	Stderr         *Output
 	verbosity      uint
 	decorated      bool
 	formatter      *OutputFormatter
 	lineLength     int
+	width          int
+	height         int
 	bufferedOutput *TrimmedBufferOutput
 	input          *Input
 	Logger
-	// progressBar    *ProgressBar
+	progressBar *ProgressBar
+	section     *ConsoleSectionOutput
+	sections    *[]*ConsoleSectionOutput
+	sectionsMu  *sync.Mutex
 }
 
 const (
@@ -41,22 +55,24 @@ const (
 	OutputPlain  uint = 4
 )
 
-func setupNewOutput(input *Input, stream *os.File, formatter *OutputFormatter) *Output {
+func setupNewOutput(input *Input, stream io.Writer, formatter *OutputFormatter) *Output {
 	o := &Output{
 		Stream:     stream,
 		verbosity:  VerbosityNormal,
-		decorated:  HasColorSupport(),
+		decorated:  streamHasColorSupport(stream),
 		formatter:  formatter,
 		lineLength: maxLineLength,
 		input:      input,
+		sectionsMu: &sync.Mutex{},
 		bufferedOutput: &TrimmedBufferOutput{
 			Output: &Output{
 				Stream:     stream,
 				verbosity:  VerbosityNormal,
-				decorated:  HasColorSupport(),
+				decorated:  streamHasColorSupport(stream),
 				formatter:  formatter,
 				lineLength: maxLineLength,
 				input:      input,
+				sectionsMu: &sync.Mutex{},
 			},
 		},
 	}
@@ -67,23 +83,77 @@ func setupNewOutput(input *Input, stream *os.File, formatter *OutputFormatter) *
 		formatter.Decorated = o.decorated
 	}
 
-	width := terminal.Columns()
-	if width == 0 {
-		width = maxLineLength
+	if w, _, ok := terminal.StreamSize(stream); ok && w > 0 {
+		o.lineLength = min(w, maxLineLength)
 	}
 
-	o.lineLength = min(width, maxLineLength)
-
 	return o
 }
 
 func NewOutput(input *Input) *Output {
+	return NewStreamOutput(input, os.Stdout, os.Stderr)
+}
+
+// NewStreamOutput creates an output that writes to the given streams. Streams that are
+// not backed by a file, such as buffers, are not decorated by default.
+func NewStreamOutput(input *Input, stdout io.Writer, stderr io.Writer) *Output {
 	f := &OutputFormatter{}
-	o := setupNewOutput(input, os.Stdout, f)
-	o.Stderr = setupNewOutput(input, os.Stderr, f)
+	o := setupNewOutput(input, stdout, f)
+	o.Stderr = setupNewOutput(input, stderr, f)
 	return o
 }
 
+// streamHasColorSupport reports whether output written to the stream is decorated by
+// default.
+func streamHasColorSupport(stream io.Writer) bool {
+	_, isFile := stream.(terminal.FileDescriptor)
+	_, isEmulator := stream.(terminal.Emulator)
+	return (isFile || isEmulator) && HasColorSupport()
+}
+
+// IsTerminal reports whether the output is written to a terminal.
+func (o *Output) IsTerminal() bool {
+	return terminal.IsTerminal(o.Stream)
+}
+
+// Width returns the number of columns of the terminal the output is written to, which
+// is read at every call so that it follows a resized terminal. Output that is not
+// written to a terminal is 80 columns wide, unless a size was set with SetSize.
+func (o *Output) Width() int {
+	width, _ := o.size()
+	return width
+}
+
+// Height returns the number of lines of the terminal the output is written to, see
+// Width. Output that is not written to a terminal is 24 lines high.
+func (o *Output) Height() int {
+	_, height := o.size()
+	return height
+}
+
+func (o *Output) size() (int, int) {
+	if o.width > 0 && o.height > 0 {
+		return o.width, o.height
+	}
+
+	if w, h, ok := terminal.StreamSize(o.Stream); ok && w > 0 && h > 0 {
+		return w, h
+	}
+
+	return defaultWidth, defaultHeight
+}
+
+// SetSize pins the size of the terminal the output is written to, e.g. to render for a
+// fixed size in tests. A zero size follows the terminal again.
+func (o *Output) SetSize(width int, height int) {
+	o.width, o.height = width, height
+	o.lineLength = min(o.Width(), maxLineLength)
+
+	if o.bufferedOutput != nil {
+		o.bufferedOutput.width, o.bufferedOutput.height = width, height
+	}
+}
+
 func (o *Output) Formatter() *OutputFormatter {
 	return o.formatter
 }
@@ -176,11 +246,20 @@ func (o *Output) WriteMany(messages []string, newLine bool, options uint) {
 }
 
 func (o *Output) DoWrite(message string, newLine bool) {
+	if o.section != nil {
+		o.section.DoWrite(message, newLine)
+		return
+	}
+
+	o.write(message, newLine)
+}
+
+func (o *Output) write(message string, newLine bool) {
 	if newLine {
 		message += Eol
 	}
 
-	_, err := o.Stream.WriteString(message)
+	_, err := io.WriteString(o.Stream, message)
 	if err != nil && o.IsDebug() {
 		log.Fatalln(err)
 	}
@@ -218,10 +297,12 @@ func (o *Output) SetVerbosity(verbose uint) {
 }
 
 func doSetVerbosity(o *Output, verbose uint) {
-	if o != nil {
-		o.verbosity = verbose
+	if o == nil {
+		return
 	}
 
+	o.verbosity = verbose
+
 	if o.Logger != nil {
 		var logLevel int
 		switch verbose {
@@ -243,6 +324,19 @@ func doSetVerbosity(o *Output, verbose uint) {
 	}
 }
 
+// CreateSection creates a new section of the output. Sections can be cleared and
+// overwritten independently of each other.
+func (o *Output) CreateSection() *ConsoleSectionOutput {
+	o.sectionsMu.Lock()
+	if o.sections == nil {
+		o.sections = &[]*ConsoleSectionOutput{}
+	}
+	sections := o.sections
+	o.sectionsMu.Unlock()
+
+	return newConsoleSectionOutput(o, sections, o.sectionsMu)
+}
+
 func (o *Output) Title(message string) {
 	o.autoPrependBlock()
 	messages := []string{
@@ -525,17 +619,44 @@ func (o *Output) NewLine(count int) {
 	}
 }
 
-// TODO: impl
-func (o *Output) ProgressStart(max uint) {}
+func (o *Output) CreateProgressBar(max uint) *ProgressBar {
+	p := NewProgressBar(o, int(max), 1.0/25)
 
-// TODO: impl
-func (o *Output) ProgressAdvance(step uint) {}
+	if p.output.IsDecorated() && runtime.GOOS != "windows" {
+		p.EmptyBarChar = ShadeLight
+		p.ProgressChar = ""
+		p.BarChar = ShadeHeavy
+	}
+
+	return p
+}
+
+func (o *Output) ProgressStart(max uint) {
+	o.progressBar = o.CreateProgressBar(max)
+	o.progressBar.Start(-1, 0)
+}
+
+func (o *Output) ProgressAdvance(step uint) {
+	o.ProgressBar().Advance(int(step))
+}
+
+func (o *Output) ProgressFinish() {
+	o.ProgressBar().Finish()
+	o.NewLine(2)
+	o.progressBar = nil
+}
 
-// TODO: impl
-func (o *Output) ProgressFinish() {}
+func (o *Output) ProgressBar() *ProgressBar {
+	if o.progressBar == nil {
+		o.progressBar = o.CreateProgressBar(0)
+		o.progressBar.Start(-1, 0)
+	}
+
+	return o.progressBar
+}
 
 func (o *Output) Box(title string, body string, footer string, color string, info string) {
-	o.Writeln(Box(title, body, footer, color, info), 0)
+	o.Writeln(box(o.Width(), title, body, footer, color, info), 0)
 }
 
 func (o *Output) autoPrependBlock() {
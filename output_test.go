@@ -0,0 +1,68 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+type emulatedStream struct {
+	bytes.Buffer
+	width  int
+	height int
+}
+
+func (s *emulatedStream) IsTerminal() bool {
+	return true
+}
+
+func (s *emulatedStream) Size() (int, int) {
+	return s.width, s.height
+}
+
+func TestOutputSizeIsTakenFromStream(t *testing.T) {
+	stream := &emulatedStream{width: 100, height: 30}
+	o := NewStreamOutput(NewInput(), stream, &bytes.Buffer{})
+	if o.Width() != 100 || o.Height() != 30 {
+		t.Errorf("expected the size of the terminal, got %dx%d", o.Width(), o.Height())
+	}
+
+	stream.width, stream.height = 60, 20
+	if o.Width() != 60 || o.Height() != 20 {
+		t.Errorf("expected the size of the resized terminal, got %dx%d", o.Width(), o.Height())
+	}
+
+	o.SetSize(40, 10)
+	stream.width, stream.height = 120, 50
+	if o.Width() != 40 || o.Height() != 10 {
+		t.Errorf("expected the pinned size, got %dx%d", o.Width(), o.Height())
+	}
+
+	if o.Stderr.Width() != defaultWidth || o.Stderr.Height() != defaultHeight {
+		t.Errorf("expected the default size for a buffer, got %dx%d", o.Stderr.Width(), o.Stderr.Height())
+	}
+}
+
+func TestSectionKeepsPinnedSize(t *testing.T) {
+	stream := &emulatedStream{width: 100, height: 30}
+	o := NewStreamOutput(NewInput(), stream, &bytes.Buffer{})
+	o.SetSize(40, 10)
+
+	section := o.CreateSection()
+	if section.Width() != 40 || section.Height() != 10 || section.lineLength != o.lineLength {
+		t.Errorf("expected the pinned size of the parent, got %dx%d", section.Width(), section.Height())
+	}
+}
+
+func TestBoxFitsOutputWidth(t *testing.T) {
+	stream := &emulatedStream{width: 40, height: 20}
+	o := NewStreamOutput(NewInput(), stream, &bytes.Buffer{})
+	o.SetDecorated(false)
+	o.Box("Title", "Body", "", "", "")
+
+	for _, line := range strings.Split(strings.TrimSpace(stream.String()), Eol) {
+		if width := len([]rune(line)); width > 40 {
+			t.Errorf("expected the box to fit 40 columns, got a line of %d: %q", width, line)
+		}
+	}
+}
@@ -32,7 +32,7 @@ func NewPausePrompt(i *Input, o *Output, message string) *PausePrompt {
 }
 
 func (p *PausePrompt) String() string {
-	renderer := NewRenderer()
+	renderer := newRenderer(p.output.Width())
 
 	if p.State == PromptStateCancel || p.State == PromptStateError {
 		renderer.Line("<fg=red>Aborted.</>", true)
@@ -0,0 +1,215 @@
+package cli
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"syscall"
+)
+
+// pluginGroup is the group that discovered plugins are listed under in the help output.
+var pluginGroup = CommandGroup{
+	ID:    "plugins",
+	Title: "Plugins",
+}
+
+// Plugins returns the external plugins of the root command: executables named
+// "<root>-<name>" in the PluginDir or on the PATH. Earlier directories take precedence,
+// and plugins that have the name of a subcommand are ignored. Plugins are only
+// discovered if EnablePlugins is set.
+func (c *Command) Plugins() []*Command {
+	root := c.Root()
+	if !root.EnablePlugins {
+		return nil
+	}
+
+	if root.plugins != nil {
+		return root.plugins
+	}
+
+	commands := root.All()
+	prefix := root.Name + "-"
+	root.plugins = make([]*Command, 0)
+
+	for _, dir := range root.pluginDirs() {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+
+		for _, entry := range entries {
+			name := strings.TrimSuffix(entry.Name(), executableSuffix())
+			if !strings.HasPrefix(name, prefix) || len(name) == len(prefix) {
+				continue
+			}
+
+			name = strings.TrimPrefix(name, prefix)
+			if _, exists := commands[name]; exists {
+				continue
+			}
+
+			if slices.ContainsFunc(root.plugins, func(p *Command) bool { return p.Name == name }) {
+				continue
+			}
+
+			path := filepath.Join(dir, entry.Name())
+			if isExecutable(path) {
+				root.plugins = append(root.plugins, root.newPluginCmd(name, path))
+			}
+		}
+	}
+
+	slices.SortFunc(root.plugins, func(a *Command, b *Command) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return root.plugins
+}
+
+// findPlugin returns the path of the executable of the plugin with the given name, or
+// an empty string if there is none.
+func (c *Command) findPlugin(name string) string {
+	if !c.EnablePlugins || name == "" || strings.ContainsAny(name, `/\`) {
+		return ""
+	}
+
+	executable := c.Name + "-" + name
+
+	if c.PluginDir != "" {
+		path := filepath.Join(c.PluginDir, executable+executableSuffix())
+		if isExecutable(path) {
+			return path
+		}
+	}
+
+	path, err := exec.LookPath(executable)
+	if err != nil {
+		return ""
+	}
+
+	return path
+}
+
+// findPluginCall returns the path of the plugin that is called by the arguments and the
+// index of its name, which is the first argument that is not a flag. Subcommands take
+// precedence over plugins. An empty path is returned when no plugin is called.
+func (c *Command) findPluginCall(args []string) (string, int, error) {
+	if !c.EnablePlugins || c.parent != nil {
+		return "", -1, nil
+	}
+
+	definition, err := c.Definition()
+	if err != nil {
+		return "", -1, err
+	}
+
+	for idx := 0; idx < len(args); idx++ {
+		if strings.HasPrefix(args[idx], "-") {
+			if flagValueFollows(definition, args, idx) {
+				idx++
+			}
+
+			continue
+		}
+
+		if c.commands[args[idx]] != nil {
+			return "", -1, nil
+		}
+
+		return c.findPlugin(args[idx]), idx, nil
+	}
+
+	return "", -1, nil
+}
+
+func (c *Command) pluginDirs() []string {
+	dirs := make([]string, 0)
+	if c.PluginDir != "" {
+		dirs = append(dirs, c.PluginDir)
+	}
+
+	return append(dirs, filepath.SplitList(os.Getenv("PATH"))...)
+}
+
+func (c *Command) newPluginCmd(name string, path string) *Command {
+	plugin := &Command{
+		Name:        name,
+		Description: "Plugin " + path,
+		Group:       pluginGroup.ID,
+		Arguments: []Arg{
+			&ArrayArg{
+				Name:        "args",
+				Description: "The arguments of the plugin",
+			},
+		},
+		NativeFlags: []string{},
+		RunE: func(io *IO) error {
+			return c.runPlugin(io.Input, io.Output, path, io.Array("args"))
+		},
+	}
+
+	plugin.SetParent(c)
+
+	return plugin
+}
+
+// rootPlugins returns the plugins if the command is the root command, plugins are not
+// subcommands of other commands.
+func (c *Command) rootPlugins() []*Command {
+	if c.parent != nil {
+		return nil
+	}
+
+	return c.Plugins()
+}
+
+// runPlugin runs the executable of a plugin with the given arguments, attached to the
+// streams of the command. A failing plugin exits with its exit code, and a plugin that
+// is killed by a signal exits with 128 plus the number of the signal, as in a shell.
+func (c *Command) runPlugin(i *Input, o *Output, path string, args []string) error {
+	cp := &ChildProcess{
+		Args:    append([]string{path}, args...),
+		Stdin:   i.Stream,
+		Stdout:  o.Stream,
+		Stderr:  o.Stderr.Stream,
+		Context: i.Context(),
+	}
+
+	_, err := cp.Run()
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return Exit(exitCode(exitErr), nil)
+	}
+
+	return err
+}
+
+func exitCode(err *exec.ExitError) int {
+	if status, ok := err.Sys().(syscall.WaitStatus); ok && status.Signaled() {
+		return 128 + int(status.Signal())
+	}
+
+	return err.ExitCode()
+}
+
+func executableSuffix() string {
+	if runtime.GOOS == "windows" {
+		return ".exe"
+	}
+
+	return ""
+}
+
+func isExecutable(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil || info.IsDir() {
+		return false
+	}
+
+	return runtime.GOOS == "windows" || info.Mode()&0111 != 0
+}
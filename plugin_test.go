@@ -0,0 +1,111 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestPlugins(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("plugins are shell scripts")
+	}
+
+	dir := t.TempDir()
+	script := "#!/bin/sh\necho \"args: $*\"\n[ -n \"$PLUGIN_SIGNAL\" ] && kill -\"$PLUGIN_SIGNAL\" $$\nexit \"${PLUGIN_EXIT:-0}\"\n"
+	if err := os.WriteFile(filepath.Join(dir, "app-hello"), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("PATH", "")
+
+	tests := []struct {
+		name      string
+		args      []string
+		env       map[string]string
+		commands  []*Command
+		arguments []Arg
+		code      int
+		expected  string
+	}{
+		{
+			name:     "arguments after the plugin name",
+			args:     []string{"--env", "hello", "hello", "a", "--flag"},
+			commands: []*Command{{Name: "deploy", Run: func(io *IO) {}}},
+			expected: "args: a --flag\n",
+		},
+		{
+			name:      "root without subcommands",
+			args:      []string{"hello", "a"},
+			arguments: []Arg{&ArrayArg{Name: "files"}},
+			expected:  "args: a\n",
+		},
+		{
+			name:     "exit code",
+			args:     []string{"hello"},
+			env:      map[string]string{"PLUGIN_EXIT": "3"},
+			commands: []*Command{{Name: "deploy", Run: func(io *IO) {}}},
+			code:     3,
+			expected: "args: \n",
+		},
+		{
+			name:     "killed by a signal",
+			args:     []string{"hello"},
+			env:      map[string]string{"PLUGIN_SIGNAL": "TERM"},
+			code:     128 + 15,
+			expected: "args: \n",
+		},
+		{
+			name:     "list",
+			args:     []string{"list"},
+			commands: []*Command{{Name: "deploy", Run: func(io *IO) {}}},
+			expected: "Plugins",
+		},
+		{
+			name:     "help",
+			args:     []string{"help", "hello"},
+			commands: []*Command{{Name: "deploy", Run: func(io *IO) {}}},
+			expected: "app hello",
+		},
+		{
+			name:     "completion",
+			args:     []string{ShellCompRequestCmd, ""},
+			commands: []*Command{{Name: "deploy", Run: func(io *IO) {}}},
+			expected: "hello\t",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for key, value := range test.env {
+				t.Setenv(key, value)
+			}
+
+			var out bytes.Buffer
+			app := &Command{
+				Name:          "app",
+				EnablePlugins: true,
+				PluginDir:     dir,
+				Flags: []Flag{
+					&StringFlag{Name: "env"},
+				},
+				Arguments: test.arguments,
+				Commands:  test.commands,
+				Run:       func(io *IO) {},
+			}
+			app.SetOut(&out)
+			app.SetErr(&out)
+
+			if code, err := app.Execute(test.args...); code != test.code {
+				t.Fatalf("expected exit code %d, got %d and %v", test.code, code, err)
+			}
+
+			if !strings.Contains(out.String(), test.expected) {
+				t.Errorf("expected %q in the output, got %q", test.expected, out.String())
+			}
+		})
+	}
+}
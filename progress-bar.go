@@ -1,45 +1,573 @@
 package cli
 
-// TODO: impl
-
-// const (
-// 	formatVerbose           = "verbose"
-// 	formatVeryVerbose       = "very_verbose"
-// 	formatDebug             = "debug"
-// 	formatNormal            = "normal"
-// 	formatVerboseNomax      = "verbose_nomax"
-// 	formatVeryVerbose_Nomax = "very_verbose_nomax"
-// 	formatDebugNomax        = "debug_nomax"
-// 	formatNormalNomax       = "normal_nomax"
-// )
-
-// type Formatter func(self *ProgressBar, o *Output) string
-
-// var formatters map[string]Formatter
-// var formats map[string]string
-
-// type ProgressBar struct {
-// 	barWidth                 int
-// 	barChar                  byte
-// 	emptyBarChar             byte
-// 	progressChar             byte
-// 	format                   string
-// 	internalFormat           string
-// 	redrawFreq               int
-// 	writeCount               int
-// 	lastWriteTime            int
-// 	minSecondsBetweenRedraws int
-// 	maxSecondsBetweenRedraws int
-// 	output                   *Output
-// 	step                     int
-// 	startingStep             int
-// 	max                      int
-// 	startTime                int
-// 	stepWidth                int
-// 	percent                  float32
-// 	messages                 map[string]string
-// 	overwrite                bool
-// 	previousMessage          string
-// 	cursor                   *Cursor
-// 	placeholders             map[string]Formatter
-// }
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/michielnijenhuis/cli/helper"
+)
+
+const (
+	ProgressBarFormatNormal           = "normal"
+	ProgressBarFormatVerbose          = "verbose"
+	ProgressBarFormatVeryVerbose      = "very_verbose"
+	ProgressBarFormatDebug            = "debug"
+	ProgressBarFormatNormalNomax      = "normal_nomax"
+	ProgressBarFormatVerboseNomax     = "verbose_nomax"
+	ProgressBarFormatVeryVerboseNomax = "very_verbose_nomax"
+	ProgressBarFormatDebugNomax       = "debug_nomax"
+)
+
+// ProgressBarPlaceholderFormatter renders a placeholder of a progress bar format. It
+// is called while the bar is drawn and locked, so it must not call methods of the bar
+// that lock it, such as Progress or Message.
+type ProgressBarPlaceholderFormatter func(bar *ProgressBar, o *Output) string
+
+var progressBarFormatters map[string]ProgressBarPlaceholderFormatter
+var progressBarFormats map[string]string
+
+var progressBarPlaceholderRegex = regexp.MustCompile(`(?i)%([a-z\-_]+)(?::([^%]+))?%`)
+
+type ProgressBar struct {
+	BarWidth                 int
+	BarChar                  string
+	EmptyBarChar             string
+	ProgressChar             string
+	RedrawFrequency          int
+	MinSecondsBetweenRedraws float64
+	MaxSecondsBetweenRedraws float64
+	Overwrite                bool
+	output                   *Output
+	cursor                   Cursor
+	format                   string
+	internalFormat           string
+	formatLineCount          int
+	writeCount               int
+	lastWriteTime            time.Time
+	step                     int
+	startingStep             int
+	max                      int
+	startTime                time.Time
+	stepWidth                int
+	percent                  float64
+	messages                 map[string]string
+	previousMessage          string
+	hasPreviousMessage       bool
+	placeholders             map[string]ProgressBarPlaceholderFormatter
+	mu                       sync.Mutex
+}
+
+func init() {
+	progressBarFormatters = makeDefaultProgressBarFormatters()
+	progressBarFormats = makeDefaultProgressBarFormats()
+}
+
+func makeDefaultProgressBarFormatters() map[string]ProgressBarPlaceholderFormatter {
+	return map[string]ProgressBarPlaceholderFormatter{
+		"bar": func(bar *ProgressBar, o *Output) string {
+			completeBars := bar.BarOffset()
+			display := strings.Repeat(bar.barChar(), completeBars)
+
+			if completeBars < bar.BarWidth {
+				progressChar := bar.ProgressChar
+				emptyBars := bar.BarWidth - completeBars - helper.Len(o.Formatter().RemoveDecoration(progressChar))
+				display += progressChar + strings.Repeat(bar.EmptyBarChar, max(0, emptyBars))
+			}
+
+			return display
+		},
+		"elapsed": func(bar *ProgressBar, o *Output) string {
+			return helper.FormatTime(int(time.Since(bar.startTime).Seconds()), 2)
+		},
+		"remaining": func(bar *ProgressBar, o *Output) string {
+			if bar.max == 0 {
+				return ""
+			}
+
+			return helper.FormatTime(bar.Remaining(), 2)
+		},
+		"estimated": func(bar *ProgressBar, o *Output) string {
+			if bar.max == 0 {
+				return ""
+			}
+
+			return helper.FormatTime(bar.Estimated(), 2)
+		},
+		"memory": func(bar *ProgressBar, o *Output) string {
+			var m runtime.MemStats
+			runtime.ReadMemStats(&m)
+			return helper.FormatMemory(int(m.Sys))
+		},
+		"current": func(bar *ProgressBar, o *Output) string {
+			return PadStart(fmt.Sprint(bar.step), bar.stepWidth, " ")
+		},
+		"max": func(bar *ProgressBar, o *Output) string {
+			return fmt.Sprint(bar.max)
+		},
+		"percent": func(bar *ProgressBar, o *Output) string {
+			return fmt.Sprint(int(math.Floor(bar.percent * 100)))
+		},
+	}
+}
+
+func makeDefaultProgressBarFormats() map[string]string {
+	return map[string]string{
+		ProgressBarFormatNormal:           " %current%/%max% [%bar%] %percent:3s%%",
+		ProgressBarFormatNormalNomax:      " %current% [%bar%]",
+		ProgressBarFormatVerbose:          " %current%/%max% [%bar%] %percent:3s%% %elapsed:6s%",
+		ProgressBarFormatVerboseNomax:     " %current% [%bar%] %elapsed:6s%",
+		ProgressBarFormatVeryVerbose:      " %current%/%max% [%bar%] %percent:3s%% %elapsed:6s%/%estimated:-6s%",
+		ProgressBarFormatVeryVerboseNomax: " %current% [%bar%] %elapsed:6s%",
+		ProgressBarFormatDebug:            " %current%/%max% [%bar%] %percent:3s%% %elapsed:6s%/%estimated:-6s% %memory:6s%",
+		ProgressBarFormatDebugNomax:       " %current% [%bar%] %elapsed:6s% %memory:6s%",
+	}
+}
+
+func SetProgressBarPlaceholderFormatter(name string, formatter ProgressBarPlaceholderFormatter) {
+	progressBarFormatters[name] = formatter
+}
+
+func ProgressBarPlaceholderFormatterDefinition(name string) ProgressBarPlaceholderFormatter {
+	return progressBarFormatters[name]
+}
+
+func SetProgressBarFormatDefinition(name string, format string) {
+	progressBarFormats[name] = format
+}
+
+func ProgressBarFormatDefinition(name string) string {
+	return progressBarFormats[name]
+}
+
+func NewProgressBar(o *Output, max int, minSecondsBetweenRedraws float64) *ProgressBar {
+	if o.Stderr != nil {
+		o = o.Stderr
+	}
+
+	p := &ProgressBar{
+		BarWidth:                 28,
+		EmptyBarChar:             "-",
+		ProgressChar:             ">",
+		RedrawFrequency:          1,
+		MaxSecondsBetweenRedraws: 1,
+		Overwrite:                true,
+		output:                   o,
+		cursor:                   Cursor{Output: o},
+		messages:                 make(map[string]string),
+		placeholders:             make(map[string]ProgressBarPlaceholderFormatter),
+		startTime:                time.Now(),
+	}
+
+	p.SetMaxSteps(max)
+
+	if minSecondsBetweenRedraws > 0 {
+		p.RedrawFrequency = 0
+		p.MinSecondsBetweenRedraws = minSecondsBetweenRedraws
+	}
+
+	if !o.IsDecorated() {
+		// disable overwrite when output does not support ANSI codes,
+		// and set a reasonable redraw frequency so output isn't flooded
+		p.Overwrite = false
+		p.RedrawFrequency = 0
+	}
+
+	return p
+}
+
+func (p *ProgressBar) SetPlaceholderFormatter(name string, formatter ProgressBarPlaceholderFormatter) {
+	p.placeholders[name] = formatter
+}
+
+func (p *ProgressBar) placeholderFormatter(name string) ProgressBarPlaceholderFormatter {
+	if formatter, ok := p.placeholders[name]; ok {
+		return formatter
+	}
+
+	return progressBarFormatters[name]
+}
+
+func (p *ProgressBar) SetMessage(message string, name string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if name == "" {
+		name = "message"
+	}
+
+	p.messages[name] = message
+}
+
+func (p *ProgressBar) Message(name string) string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if name == "" {
+		name = "message"
+	}
+
+	return p.messages[name]
+}
+
+func (p *ProgressBar) StartTime() time.Time {
+	return p.startTime
+}
+
+func (p *ProgressBar) MaxSteps() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.max
+}
+
+func (p *ProgressBar) Progress() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.step
+}
+
+func (p *ProgressBar) ProgressPercent() float64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.percent
+}
+
+func (p *ProgressBar) BarOffset() int {
+	if p.max > 0 {
+		return int(math.Floor(p.percent * float64(p.BarWidth)))
+	}
+
+	if p.BarWidth <= 0 {
+		return 0
+	}
+
+	if p.RedrawFrequency == 0 {
+		return int(math.Min(5, float64(p.BarWidth)/15)*float64(p.writeCount)) % p.BarWidth
+	}
+
+	return p.step % p.BarWidth
+}
+
+func (p *ProgressBar) Estimated() int {
+	if p.step == 0 || p.step == p.startingStep {
+		return 0
+	}
+
+	return int(math.Round(time.Since(p.startTime).Seconds() / float64(p.step-p.startingStep) * float64(p.max)))
+}
+
+func (p *ProgressBar) Remaining() int {
+	if p.step == 0 || p.step == p.startingStep {
+		return 0
+	}
+
+	return int(math.Round(time.Since(p.startTime).Seconds() / float64(p.step-p.startingStep) * float64(p.max-p.step)))
+}
+
+func (p *ProgressBar) barChar() string {
+	if p.BarChar != "" {
+		return p.BarChar
+	}
+
+	if p.max > 0 {
+		return "="
+	}
+
+	return p.EmptyBarChar
+}
+
+func (p *ProgressBar) SetFormat(format string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.format = ""
+	p.internalFormat = format
+}
+
+// Start starts the progress output. A negative max keeps the current maximum.
+func (p *ProgressBar) Start(max int, startAt int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.startTime = time.Now()
+	p.step = startAt
+	p.startingStep = startAt
+
+	if startAt > 0 {
+		p.setProgress(startAt)
+	} else {
+		p.percent = 0
+	}
+
+	if max >= 0 {
+		p.setMaxSteps(max)
+	}
+
+	p.display()
+}
+
+func (p *ProgressBar) Advance(step int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.setProgress(p.step + step)
+}
+
+func (p *ProgressBar) SetMaxSteps(steps int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.setMaxSteps(steps)
+}
+
+func (p *ProgressBar) setMaxSteps(steps int) {
+	p.format = ""
+	p.max = max(0, steps)
+
+	if p.max > 0 {
+		p.stepWidth = helper.Width(fmt.Sprint(p.max))
+	} else {
+		p.stepWidth = 4
+	}
+}
+
+func (p *ProgressBar) SetProgress(step int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.setProgress(step)
+}
+
+func (p *ProgressBar) setProgress(step int) {
+	if p.max > 0 && step > p.max {
+		p.max = step
+	} else if step < 0 {
+		step = 0
+	}
+
+	redrawFreq := float64(p.RedrawFrequency)
+	if redrawFreq == 0 {
+		redrawFreq = float64(p.max) / 10
+	}
+
+	var prevPeriod, currPeriod int
+	if redrawFreq > 0 {
+		prevPeriod = int(float64(p.step) / redrawFreq)
+		currPeriod = int(float64(step) / redrawFreq)
+	}
+
+	p.step = step
+
+	if p.max > 0 {
+		p.percent = float64(p.step) / float64(p.max)
+	} else {
+		p.percent = 0
+	}
+
+	timeInterval := time.Since(p.lastWriteTime).Seconds()
+
+	// draw regardless of other limits
+	if p.max == step {
+		p.display()
+		return
+	}
+
+	// throttling
+	if timeInterval < p.MinSecondsBetweenRedraws {
+		return
+	}
+
+	// draw each step period, but not too late
+	if prevPeriod != currPeriod || timeInterval >= p.MaxSecondsBetweenRedraws {
+		p.display()
+	}
+}
+
+func (p *ProgressBar) Finish() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.max == 0 {
+		p.max = p.step
+	}
+
+	if p.step == p.max && !p.Overwrite {
+		// prevent double 100% output
+		return
+	}
+
+	p.setProgress(p.max)
+}
+
+func (p *ProgressBar) Display() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.display()
+}
+
+func (p *ProgressBar) display() {
+	if p.output.IsQuiet() {
+		return
+	}
+
+	if p.format == "" {
+		p.setRealFormat(p.resolveFormat())
+	}
+
+	p.overwrite(p.buildLine())
+}
+
+func (p *ProgressBar) Clear() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if !p.Overwrite {
+		return
+	}
+
+	if p.format == "" {
+		p.setRealFormat(p.resolveFormat())
+	}
+
+	p.overwrite("")
+}
+
+func (p *ProgressBar) resolveFormat() string {
+	if p.internalFormat != "" {
+		return p.internalFormat
+	}
+
+	return p.determineBestFormat()
+}
+
+func (p *ProgressBar) setRealFormat(format string) {
+	if p.max == 0 && ProgressBarFormatDefinition(format+"_nomax") != "" {
+		p.format = ProgressBarFormatDefinition(format + "_nomax")
+	} else if definition := ProgressBarFormatDefinition(format); definition != "" {
+		p.format = definition
+	} else {
+		p.format = format
+	}
+
+	p.formatLineCount = strings.Count(p.format, Eol)
+}
+
+func (p *ProgressBar) determineBestFormat() string {
+	var format string
+	switch p.output.Verbosity() {
+	case VerbosityVerbose:
+		format = ProgressBarFormatVerbose
+	case VerbosityVeryVerbose:
+		format = ProgressBarFormatVeryVerbose
+	case VerbosityDebug:
+		format = ProgressBarFormatDebug
+	default:
+		format = ProgressBarFormatNormal
+	}
+
+	if p.max == 0 {
+		format += "_nomax"
+	}
+
+	return format
+}
+
+func (p *ProgressBar) overwrite(message string) {
+	if p.hasPreviousMessage && p.previousMessage == message {
+		return
+	}
+
+	originalMessage := message
+
+	if p.Overwrite {
+		if p.hasPreviousMessage && p.output.section != nil {
+			p.output.section.Clear(p.previousLineCount())
+		} else if p.hasPreviousMessage {
+			lineCount := strings.Count(p.previousMessage, Eol)
+			for i := 0; i < lineCount; i++ {
+				p.cursor.MoveToColumn(1)
+				p.cursor.ClearLine()
+				p.cursor.MoveUp(1)
+			}
+
+			p.cursor.MoveToColumn(1)
+			p.cursor.ClearLine()
+		}
+	} else if p.step > 0 {
+		message = Eol + message
+	}
+
+	p.previousMessage = originalMessage
+	p.hasPreviousMessage = true
+	p.lastWriteTime = time.Now()
+
+	if message != "" {
+		p.output.Write(message, false, 0)
+	}
+
+	p.writeCount++
+}
+
+func (p *ProgressBar) previousLineCount() int {
+	messageLines := strings.Split(p.previousMessage, Eol)
+	lineCount := len(messageLines)
+	terminalWidth := p.output.Width()
+
+	for _, messageLine := range messageLines {
+		messageLineLength := helper.Width(p.output.Formatter().RemoveDecoration(messageLine))
+		if terminalWidth > 0 && messageLineLength > terminalWidth {
+			lineCount += messageLineLength / terminalWidth
+		}
+	}
+
+	return lineCount
+}
+
+func (p *ProgressBar) buildLine() string {
+	line := p.replacePlaceholders()
+
+	// gets string length for each sub line with multiline format
+	linesWidth := 0
+	for _, subLine := range strings.Split(line, Eol) {
+		linesWidth = max(linesWidth, helper.Width(p.output.Formatter().RemoveDecoration(strings.TrimRight(subLine, "\r"))))
+	}
+
+	terminalWidth := p.output.Width()
+	if linesWidth <= terminalWidth {
+		return line
+	}
+
+	p.BarWidth = max(1, p.BarWidth-linesWidth+terminalWidth)
+
+	return p.replacePlaceholders()
+}
+
+func (p *ProgressBar) replacePlaceholders() string {
+	return progressBarPlaceholderRegex.ReplaceAllStringFunc(p.format, func(match string) string {
+		submatches := progressBarPlaceholderRegex.FindStringSubmatch(match)
+		name := submatches[1]
+
+		var text string
+		if formatter := p.placeholderFormatter(name); formatter != nil {
+			text = formatter(p, p.output)
+		} else if message, ok := p.messages[name]; ok {
+			text = message
+		} else {
+			return match
+		}
+
+		if submatches[2] != "" {
+			text = fmt.Sprintf("%"+submatches[2], text)
+		}
+
+		return text
+	})
+}
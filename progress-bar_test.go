@@ -0,0 +1,126 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestProgressBarRendersFormats(t *testing.T) {
+	tests := []struct {
+		name     string
+		max      int
+		run      func(p *ProgressBar)
+		expected []string
+	}{
+		{
+			name: "normal format",
+			max:  10,
+			run: func(p *ProgressBar) {
+				p.Start(-1, 0)
+				p.Advance(5)
+				p.Finish()
+			},
+			expected: []string{
+				"  0/10 [>---------------------------]   0%",
+				"  5/10 [==============>-------------]  50%",
+				" 10/10 [============================] 100%",
+			},
+		},
+		{
+			name: "custom format with placeholders and messages",
+			max:  4,
+			run: func(p *ProgressBar) {
+				p.BarWidth = 4
+				p.SetFormat("%title% %current%/%max% [%bar%] %percent:3s%% %status%")
+				p.SetMessage("Importing", "title")
+				p.SetPlaceholderFormatter("status", func(bar *ProgressBar, o *Output) string {
+					if bar.step == bar.max {
+						return "done"
+					}
+
+					return "busy"
+				})
+				p.Start(-1, 2)
+				p.Finish()
+			},
+			expected: []string{
+				"",
+				"Importing 2/4 [==>-]  50% busy",
+				"Importing 4/4 [====] 100% done",
+			},
+		},
+		{
+			name: "format definition without max",
+			max:  0,
+			run: func(p *ProgressBar) {
+				p.SetFormat(ProgressBarFormatVerbose)
+				p.Start(-1, 0)
+			},
+			expected: []string{
+				"    0 [>---------------------------] < 1 sec",
+			},
+		},
+		{
+			name: "throttled redraws",
+			max:  100,
+			run: func(p *ProgressBar) {
+				p.MinSecondsBetweenRedraws = 60
+				p.Start(-1, 0)
+				for range 99 {
+					p.Advance(1)
+				}
+				p.Finish()
+			},
+			expected: []string{
+				"   0/100 [>---------------------------]   0%",
+				" 100/100 [============================] 100%",
+			},
+		},
+		{
+			name: "redraw frequency",
+			max:  10,
+			run: func(p *ProgressBar) {
+				p.RedrawFrequency = 4
+				p.Start(-1, 0)
+				for range 10 {
+					p.Advance(1)
+				}
+			},
+			expected: []string{
+				"  0/10 [>---------------------------]   0%",
+				"  4/10 [===========>----------------]  40%",
+				"  8/10 [======================>-----]  80%",
+				" 10/10 [============================] 100%",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var out bytes.Buffer
+			o := NewStreamOutput(NewInput(), &out, &out)
+			test.run(NewProgressBar(o, test.max, 0))
+
+			if expected := strings.Join(test.expected, Eol); out.String() != expected {
+				t.Errorf("expected %q, got %q", expected, out.String())
+			}
+		})
+	}
+}
+
+func TestProgressBarWithoutMaxIsFinishedAtItsProgress(t *testing.T) {
+	var out bytes.Buffer
+	p := NewProgressBar(NewStreamOutput(NewInput(), &out, &out), 0, 0)
+	p.Start(-1, 0)
+	p.Advance(7)
+
+	if p.MaxSteps() != 0 || p.ProgressPercent() != 0 {
+		t.Errorf("expected no max and no percentage, got %d and %v", p.MaxSteps(), p.ProgressPercent())
+	}
+
+	p.Finish()
+	if p.MaxSteps() != 7 || p.Progress() != 7 {
+		t.Errorf("expected the bar to be finished at 7 steps, got %d/%d", p.Progress(), p.MaxSteps())
+	}
+}
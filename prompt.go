@@ -1,14 +1,13 @@
 package cli
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"os"
 	"strings"
 
 	"github.com/michielnijenhuis/cli/helper"
 	"github.com/michielnijenhuis/cli/helper/keys"
-	"github.com/michielnijenhuis/cli/terminal"
 )
 
 const (
@@ -86,6 +85,10 @@ func (p *Prompt) doPrompt(renderer func() string) (string, error) {
 		return p.defaultValue()
 	}
 
+	if err := p.input.Context().Err(); err != nil {
+		return "", context.Cause(p.input.Context())
+	}
+
 	var err error
 	var answer string
 
@@ -101,17 +104,13 @@ func (p *Prompt) doPrompt(renderer func() string) (string, error) {
 
 	p.render(renderer)
 
-	stream := p.input.Stream
-	buffer := make([]byte, 3)
-
 	for {
-		read, err := stream.Read(buffer)
+		key, err := p.readKey()
 		if err != nil {
 			p.Restore(false)
 			return "", err
 		}
 
-		key := string(buffer[:read])
 		if key == "" {
 			break
 		}
@@ -126,8 +125,8 @@ func (p *Prompt) doPrompt(renderer func() string) (string, error) {
 					break
 				} else {
 					p.Restore(true)
-					os.Exit(1)
-					break
+					p.input.interrupt()
+					return "", ErrInterrupted
 				}
 			}
 
@@ -145,6 +144,12 @@ func (p *Prompt) doPrompt(renderer func() string) (string, error) {
 	return answer, nil
 }
 
+// readKey reads the next key press, or returns the cause of the command
+// context being cancelled while waiting for it.
+func (p *Prompt) readKey() (string, error) {
+	return p.input.readKey()
+}
+
 func (p *Prompt) Restore(force bool) {
 	if !p.isChild || force {
 		_ = p.input.RestoreTty()
@@ -379,7 +384,7 @@ func (p *Prompt) Resetvalue() {
 
 func (p *Prompt) AddCursor(value string, cursorPosition int, maxWidth int) string {
 	if maxWidth <= 0 {
-		tw := terminal.Columns()
+		tw := p.output.Width()
 		maxWidth = tw
 	}
 
@@ -464,7 +469,7 @@ func (p *Prompt) InitializeScrolling(highlighted int, reservedLines int) {
 }
 
 func (p *Prompt) ReduceScrollingToFitTerminal(reservedLines int) {
-	terminalHeight := terminal.Lines()
+	terminalHeight := p.output.Height()
 	p.Scroll = max(1, min(p.Scroll, terminalHeight-reservedLines))
 }
 
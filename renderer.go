@@ -10,11 +10,19 @@ import (
 type Renderer struct {
 	output   strings.Builder
 	minWidth int
+	width    int
 }
 
+// NewRenderer creates a renderer for the terminal of stdin. Prompts render for the
+// terminal of their output instead.
 func NewRenderer() *Renderer {
+	return newRenderer(terminal.Columns())
+}
+
+func newRenderer(width int) *Renderer {
 	return &Renderer{
 		minWidth: 60,
+		width:    width,
 	}
 }
 
@@ -45,8 +53,7 @@ func (r *Renderer) Hint(message string) {
 		return
 	}
 
-	terminalWidth := terminal.Columns()
-	message = TruncateStart(message, terminalWidth-6)
+	message = TruncateStart(message, r.width-6)
 
 	r.Line(fmt.Sprintf("  <fg=gray>%s</>", message), true)
 }
@@ -7,7 +7,6 @@ import (
 	"strings"
 
 	"github.com/michielnijenhuis/cli/helper/keys"
-	"github.com/michielnijenhuis/cli/terminal"
 )
 
 // May be a map[string]string or []string
@@ -98,8 +97,8 @@ func (p *SearchPrompt) search() {
 }
 
 func (p *SearchPrompt) View() string {
-	renderer := NewRenderer()
-	terminalWidth := terminal.Columns()
+	renderer := newRenderer(p.output.Width())
+	terminalWidth := p.output.Width()
 	maxWidth := terminalWidth - 6
 	state := p.State
 
@@ -235,7 +234,7 @@ func (p *SearchPrompt) renderOptions() string {
 		return fmt.Sprintf("<fg=gray>  %s</>", text)
 	}
 
-	terminalWidth := terminal.Columns()
+	terminalWidth := p.output.Width()
 	matches := p.Matches()
 	visible := p.Visible()
 	items := make([]string, len(visible))
@@ -258,7 +257,7 @@ func (p *SearchPrompt) spaceForDropdown() string {
 		return ""
 	}
 
-	terminalHeight := terminal.Lines()
+	terminalHeight := p.output.Height()
 
 	newLines := min(p.Scroll, terminalHeight-len(p.Matches()))
 	if len(p.Matches()) == 0 {
@@ -6,7 +6,6 @@ import (
 
 	"github.com/michielnijenhuis/cli/helper/array"
 	"github.com/michielnijenhuis/cli/helper/keys"
-	"github.com/michielnijenhuis/cli/terminal"
 )
 
 type SelectPrompt struct {
@@ -35,7 +34,7 @@ func NewSelectPrompt(i *Input, o *Output, label string, values []string, labels
 
 	p.Required = true
 	p.GetValue = func() string {
-		if !terminal.IsInteractive() {
+		if !i.IsInteractive() {
 			return p.DefaultValue
 		}
 
@@ -106,8 +105,8 @@ func (p *SelectPrompt) Visible() []string {
 }
 
 func (p *SelectPrompt) String() string {
-	renderer := NewRenderer()
-	maxWidth := terminal.Columns() - 6
+	renderer := newRenderer(p.output.Width())
+	maxWidth := p.output.Width() - 6
 	state := p.State
 
 	if state == PromptStateSubmit {
@@ -128,7 +127,7 @@ func (p *SelectPrompt) String() string {
 }
 
 func (p *SelectPrompt) renderOptions() string {
-	width := terminal.Columns()
+	width := p.output.Width()
 	visible := p.Visible()
 	values := p.Values
 	color := ColorCyan
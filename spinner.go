@@ -4,10 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"os"
-	"os/signal"
 	"strings"
-	"syscall"
 	"time"
 )
 
@@ -97,13 +94,12 @@ func RenderSpinner(s *Spinner) string {
 
 var ErrCancelledSpinner = errors.New("cancelled")
 
+// Spin runs fn while showing the spinner. When the command context is cancelled
+// while spinning, the cancel message is rendered once fn has returned.
 func (s *Spinner) Spin(fn func()) {
 	s.cursor.Hide()
 
-	sigs := make(chan os.Signal, 2)
-	signal.Notify(sigs, os.Interrupt, syscall.SIGINT, syscall.SIGTERM)
-
-	ctx, cancel := context.WithCancel(context.Background())
+	ctx, cancel := context.WithCancel(s.input.Context())
 	done := make(chan bool)
 
 	go func(c context.Context) {
@@ -123,14 +119,12 @@ func (s *Spinner) Spin(fn func()) {
 		done <- true
 	}(fn, done)
 
-	go func(s *Spinner) {
-		<-sigs
-		s.State = PromptStateCancel
-		cancel()
-	}(s)
-
 	<-done
 
+	if s.input.Context().Err() != nil {
+		s.State = PromptStateCancel
+	}
+
 	if !s.KeepRenderedLines {
 		s.eraseRenderedLines()
 	}
@@ -141,7 +135,6 @@ func (s *Spinner) Spin(fn func()) {
 	if s.State == PromptStateCancel {
 		s.Render(RenderSpinner(s))
 		s.output.NewLine(1)
-		os.Exit(1)
 	}
 }
 
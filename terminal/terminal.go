@@ -6,6 +6,18 @@ import (
 	"golang.org/x/term"
 )
 
+// FileDescriptor is implemented by streams that are backed by a file, such as *os.File.
+type FileDescriptor interface {
+	Fd() uintptr
+}
+
+// Emulator is implemented by streams that emulate a terminal without being backed by
+// a file, such as fake terminals in tests.
+type Emulator interface {
+	IsTerminal() bool
+	Size() (int, int)
+}
+
 func Columns() int {
 	width, _, err := term.GetSize(0)
 	if err != nil {
@@ -36,3 +48,35 @@ func Size() (int, int) {
 func IsInteractive() bool {
 	return term.IsTerminal(int(os.Stdin.Fd()))
 }
+
+// IsTerminal reports whether the stream is a terminal. Streams that are not backed by
+// a file, such as buffers, are only a terminal if they implement Emulator.
+func IsTerminal(stream any) bool {
+	if e, ok := stream.(Emulator); ok {
+		return e.IsTerminal()
+	}
+
+	f, ok := stream.(FileDescriptor)
+	return ok && term.IsTerminal(int(f.Fd()))
+}
+
+// StreamSize returns the width and height of the terminal of the stream, and false if
+// the stream is not a terminal.
+func StreamSize(stream any) (int, int, bool) {
+	if e, ok := stream.(Emulator); ok {
+		w, h := e.Size()
+		return w, h, e.IsTerminal()
+	}
+
+	f, ok := stream.(FileDescriptor)
+	if !ok {
+		return 0, 0, false
+	}
+
+	w, h, err := term.GetSize(int(f.Fd()))
+	if err != nil {
+		return 0, 0, false
+	}
+
+	return w, h, true
+}
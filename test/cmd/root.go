@@ -3,6 +3,7 @@ package cmd
 import (
 	"fmt"
 	"log"
+	"os"
 
 	"github.com/michielnijenhuis/cli"
 )
@@ -125,7 +126,8 @@ func Execute() {
 	rootCmd.AddCommand(childA)
 	rootCmd.AddCommand(childA2)
 
-	if err := rootCmd.Execute(); err != nil {
-		log.Fatalln(err)
+	if code, err := rootCmd.Execute(); err != nil {
+		log.Println(err)
+		os.Exit(code)
 	}
 }
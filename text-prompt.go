@@ -2,8 +2,6 @@ package cli
 
 import (
 	"fmt"
-
-	"github.com/michielnijenhuis/cli/terminal"
 )
 
 type TextPrompt struct {
@@ -39,8 +37,8 @@ func (p *TextPrompt) ValueWithCursor(maxWidth int) string {
 }
 
 func (p *TextPrompt) View() string {
-	renderer := NewRenderer()
-	maxWidth := terminal.Columns() - 6
+	renderer := newRenderer(p.output.Width())
+	maxWidth := p.output.Width() - 6
 	state := p.State
 
 	if state == PromptStateSubmit {
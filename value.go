@@ -0,0 +1,374 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Value is the value of a flag. Every flag is parsed through a Value, implement it and
+// use a ValueFlag to add custom flag kinds, such as enums, key=value maps or sizes.
+type Value interface {
+	Set(string) error
+	String() string
+	Type() string
+}
+
+// BoolValue can be implemented by a Value that does not accept a value on the command
+// line, like a BoolFlag.
+type BoolValue interface {
+	Value
+	IsBool() bool
+}
+
+// SliceValue can be implemented by a Value that accepts multiple values. Set is called
+// once for every value given, the first call should replace the default values, like
+// the values of an ArrayFlag do. Replace sets the values, and the next call to Set
+// replaces them again; it restores the default values before every execution.
+type SliceValue interface {
+	Value
+	IsSlice() bool
+	GetSlice() []string
+	Replace([]string) error
+}
+
+// OptionalValue can be implemented by a Value that may be given without a value on the
+// command line, like an OptionalStringFlag. Set is called with an empty string then.
+type OptionalValue interface {
+	Value
+	IsOptional() bool
+}
+
+type ValueFlag struct {
+	Name         string
+	Shortcuts    []string
+	Description  string
+	EnvVars      []string
+	Required     bool
+	Deprecated   string
+	ReplacedBy   string
+	Value        Value
+	Negatable    bool
+	Validator    func(Value) error
+	CompleteFunc CompletionFunc
+	flagState
+}
+
+func (f *ValueFlag) GetName() string {
+	return f.Name
+}
+
+func (f *ValueFlag) GetShortcuts() []string {
+	return f.Shortcuts
+}
+
+func (f *ValueFlag) GetShortcutString() string {
+	return joinShortcuts(f.Shortcuts)
+}
+
+func (f *ValueFlag) GetDescription() string {
+	return f.Description
+}
+
+func (f *ValueFlag) GetEnvVars() []string {
+	return f.EnvVars
+}
+
+func (f *ValueFlag) GetCompleteFunc() CompletionFunc {
+	return f.CompleteFunc
+}
+
+func (f *ValueFlag) IsRequired() bool {
+	return f.Required
+}
+
+func (f *ValueFlag) GetDeprecated() string {
+	return f.Deprecated
+}
+
+func (f *ValueFlag) GetReplacedBy() string {
+	return f.ReplacedBy
+}
+
+func (f *ValueFlag) HasValue() bool {
+	if f.Value == nil {
+		return false
+	}
+
+	if s, ok := f.Value.(SliceValue); ok && s.IsSlice() {
+		return len(s.GetSlice()) > 0
+	}
+
+	if f.IsBool() {
+		return f.Value.String() == "true"
+	}
+
+	return f.Value.String() != ""
+}
+
+// Opts returns the options of the value, if it implements HasOptions. The options are
+// used for shell completion, validating them is up to Set.
+func (f *ValueFlag) Opts() []string {
+	if opts, ok := f.Value.(HasOptions); ok {
+		return opts.Opts()
+	}
+
+	return nil
+}
+
+func (f *ValueFlag) IsBool() bool {
+	b, ok := f.Value.(BoolValue)
+	return ok && b.IsBool()
+}
+
+func (f *ValueFlag) IsSlice() bool {
+	s, ok := f.Value.(SliceValue)
+	return ok && s.IsSlice()
+}
+
+// setValue sets the value from the string, describing a failure with the kind and name
+// of the flag or argument it belongs to.
+func setValue(value Value, str string, kind string, name string) error {
+	if value == nil {
+		return fmt.Errorf("%s \"%s\" has no value", kind, name)
+	}
+
+	if err := value.Set(str); err != nil {
+		return fmt.Errorf("invalid value \"%s\" for %s \"%s\": %s", str, kind, name, err)
+	}
+
+	return nil
+}
+
+type stringValue string
+
+func (v *stringValue) Set(s string) error {
+	*v = stringValue(s)
+	return nil
+}
+
+func (v *stringValue) String() string {
+	return string(*v)
+}
+
+func (v *stringValue) Type() string {
+	return "string"
+}
+
+type boolValue bool
+
+func (v *boolValue) Set(s string) error {
+	b, err := strconv.ParseBool(s)
+	if err != nil {
+		return errors.New("expected a boolean")
+	}
+
+	*v = boolValue(b)
+	return nil
+}
+
+func (v *boolValue) String() string {
+	return strconv.FormatBool(bool(*v))
+}
+
+func (v *boolValue) Type() string {
+	return "bool"
+}
+
+func (v *boolValue) IsBool() bool {
+	return true
+}
+
+type intValue int
+
+func (v *intValue) Set(s string) error {
+	i, err := strconv.Atoi(s)
+	if err != nil {
+		return errors.New("expected an integer")
+	}
+
+	*v = intValue(i)
+	return nil
+}
+
+func (v *intValue) String() string {
+	return strconv.Itoa(int(*v))
+}
+
+func (v *intValue) Type() string {
+	return "int"
+}
+
+type uintValue uint
+
+func (v *uintValue) Set(s string) error {
+	u, err := strconv.ParseUint(s, 10, 0)
+	if err != nil {
+		return errors.New("expected a positive integer")
+	}
+
+	*v = uintValue(u)
+	return nil
+}
+
+func (v *uintValue) String() string {
+	return strconv.FormatUint(uint64(*v), 10)
+}
+
+func (v *uintValue) Type() string {
+	return "uint"
+}
+
+type floatValue float64
+
+func (v *floatValue) Set(s string) error {
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return errors.New("expected a number")
+	}
+
+	*v = floatValue(f)
+	return nil
+}
+
+func (v *floatValue) String() string {
+	return strconv.FormatFloat(float64(*v), 'g', -1, 64)
+}
+
+func (v *floatValue) Type() string {
+	return "float"
+}
+
+type durationValue time.Duration
+
+func (v *durationValue) Set(s string) error {
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return errors.New("expected a duration, e.g. \"1h30m\"")
+	}
+
+	*v = durationValue(d)
+	return nil
+}
+
+func (v *durationValue) String() string {
+	return time.Duration(*v).String()
+}
+
+func (v *durationValue) Type() string {
+	return "duration"
+}
+
+type timeValue struct {
+	value  *time.Time
+	layout string
+}
+
+func (v *timeValue) Set(s string) error {
+	t, err := time.Parse(v.Layout(), s)
+	if err != nil {
+		return fmt.Errorf("expected a time formatted as \"%s\"", v.Layout())
+	}
+
+	*v.value = t
+	return nil
+}
+
+func (v *timeValue) String() string {
+	return v.value.Format(v.Layout())
+}
+
+func (v *timeValue) Type() string {
+	return "time"
+}
+
+func (v *timeValue) Layout() string {
+	if v.layout == "" {
+		return time.RFC3339
+	}
+
+	return v.layout
+}
+
+// stringSliceValue appends every value that is set. The first value replaces the
+// default values, unless the value was changed before.
+type stringSliceValue struct {
+	value   *[]string
+	changed bool
+}
+
+func (v *stringSliceValue) Set(s string) error {
+	if !v.changed {
+		*v.value = make([]string, 0)
+		v.changed = true
+	}
+
+	*v.value = append(*v.value, s)
+	return nil
+}
+
+func (v *stringSliceValue) String() string {
+	return strings.Join(*v.value, ",")
+}
+
+func (v *stringSliceValue) Type() string {
+	return "strings"
+}
+
+func (v *stringSliceValue) IsSlice() bool {
+	return true
+}
+
+func (v *stringSliceValue) Replace(values []string) error {
+	*v.value = slices.Clone(values)
+	v.changed = false
+	return nil
+}
+
+func (v *stringSliceValue) GetSlice() []string {
+	if *v.value == nil {
+		return []string{}
+	}
+
+	return *v.value
+}
+
+type optionalStringValue struct {
+	value   *string
+	boolean *bool
+}
+
+func (v *optionalStringValue) Set(s string) error {
+	*v.value = s
+	*v.boolean = true
+	return nil
+}
+
+func (v *optionalStringValue) String() string {
+	return *v.value
+}
+
+func (v *optionalStringValue) Type() string {
+	return "string"
+}
+
+func (v *optionalStringValue) IsOptional() bool {
+	return true
+}
+
+type optionalSliceValue struct {
+	stringSliceValue
+	boolean *bool
+}
+
+func (v *optionalSliceValue) Set(s string) error {
+	*v.boolean = true
+	return v.stringSliceValue.Set(s)
+}
+
+func (v *optionalSliceValue) IsOptional() bool {
+	return true
+}
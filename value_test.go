@@ -0,0 +1,137 @@
+package cli
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+)
+
+type regionValue string
+
+func (v *regionValue) Set(s string) error {
+	if !slices.Contains(v.Opts(), s) {
+		return fmt.Errorf("expected one of: %s", strings.Join(v.Opts(), ", "))
+	}
+
+	*v = regionValue(s)
+	return nil
+}
+
+func (v *regionValue) String() string {
+	return string(*v)
+}
+
+func (v *regionValue) Type() string {
+	return "region"
+}
+
+func (v *regionValue) Opts() []string {
+	return []string{"eu", "us"}
+}
+
+func bindFlags(t *testing.T, flags []Flag, args ...string) (*Input, error) {
+	t.Helper()
+
+	definition := &InputDefinition{}
+	if err := definition.SetFlags(flags); err != nil {
+		t.Fatalf("unexpected definition error: %v", err)
+	}
+
+	i := NewInput(args...)
+	i.Strict = true
+	if err := i.Bind(definition); err != nil {
+		return i, err
+	}
+
+	return i, i.Validate()
+}
+
+func TestValueFlagIsParsedThroughValue(t *testing.T) {
+	region := regionValue("eu")
+	flag := &ValueFlag{Name: "region", Value: &region}
+
+	if !FlagRequiresValue(flag) || FlagIsArray(flag) {
+		t.Errorf("expected a single value flag")
+	}
+
+	if formatFlagValue(flag) != "eu" {
+		t.Errorf("expected default \"eu\", got %q", formatFlagValue(flag))
+	}
+
+	if _, err := bindFlags(t, []Flag{flag}, "--region", "us"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if region != "us" {
+		t.Errorf("expected region \"us\", got %q", region)
+	}
+}
+
+func TestValueFlagReportsSetErrors(t *testing.T) {
+	region := regionValue("eu")
+	flag := &ValueFlag{Name: "region", Value: &region}
+
+	_, err := bindFlags(t, []Flag{flag}, "--region=ap")
+	if err == nil {
+		t.Fatalf("expected an error for an invalid region")
+	}
+
+	expected := `invalid value "ap" for flag "region": expected one of: eu, us`
+	if err.Error() != expected {
+		t.Errorf("expected error %q, got %q", expected, err.Error())
+	}
+}
+
+func TestSliceValuesReplaceTheirDefault(t *testing.T) {
+	flag := &ArrayFlag{Name: "tag", Value: []string{"latest"}}
+
+	if formatFlagValue(flag) != "[latest]" {
+		t.Errorf("expected default \"[latest]\", got %q", formatFlagValue(flag))
+	}
+
+	if _, err := bindFlags(t, []Flag{flag}, "--tag=a", "--tag=b"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !slices.Equal(flag.Value, []string{"a", "b"}) {
+		t.Errorf("expected [a b], got %v", flag.Value)
+	}
+}
+
+func TestBuiltinFlagsExposeTheirValue(t *testing.T) {
+	limit := &IntFlag{Name: "limit", Value: 10}
+	verbose := &BoolFlag{Name: "verbose"}
+
+	i, err := bindFlags(t, []Flag{limit, verbose}, "--limit=20", "--verbose")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for name, expected := range map[string]string{"limit": "20", "verbose": "true"} {
+		value, err := i.Value(name)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if value.String() != expected {
+			t.Errorf("expected %s to be %q, got %q", name, expected, value.String())
+		}
+	}
+
+	if _, err := bindFlags(t, []Flag{&IntFlag{Name: "limit"}}, "--limit=ten"); err == nil || !strings.Contains(err.Error(), "expected an integer") {
+		t.Errorf("expected an integer parse error, got %v", err)
+	}
+}
+
+func TestNegatedBoolValuesAreSetToFalse(t *testing.T) {
+	flag := &BoolFlag{Name: "cache", Value: true, Negatable: true}
+
+	if _, err := bindFlags(t, []Flag{flag}, "--no-cache"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if flag.Value {
+		t.Errorf("expected \"--no-cache\" to set the flag to false")
+	}
+}
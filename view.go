@@ -4,8 +4,6 @@ import (
 	"fmt"
 	"math"
 	"strings"
-
-	"github.com/michielnijenhuis/cli/terminal"
 )
 
 type View struct {
@@ -33,7 +31,7 @@ func (v *View) ShowCursor() {
 }
 
 func (v *View) Clear() {
-	terminalHeight := terminal.Lines()
+	terminalHeight := v.output.Height()
 	previousFrameHeight := v.prevHeight()
 	v.cursor.MoveToColumn(1)
 	up := min(terminalHeight, previousFrameHeight) - 1
@@ -57,7 +55,7 @@ func (v *View) Render(frame string) {
 
 	v.Clear()
 
-	terminalHeight := terminal.Lines()
+	terminalHeight := v.output.Height()
 	previousFrameHeight := v.prevHeight()
 
 	start := int(math.Abs(float64(min(0, terminalHeight-previousFrameHeight))))
@@ -25,7 +25,7 @@ func NewWaitPrompt(i *Input, o *Output, waitFunc func() bool, message string) *W
 }
 
 func (p *WaitPrompt) String() string {
-	renderer := NewRenderer()
+	renderer := newRenderer(p.output.Width())
 	state := p.State
 
 	if state == PromptStateCancel {
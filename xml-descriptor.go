@@ -0,0 +1,40 @@
+package cli
+
+import (
+	"encoding/xml"
+)
+
+type XmlDescriptor struct {
+	Output *Output
+}
+
+func (d *XmlDescriptor) DescribeCommand(command *Command, options *DescriptorOptions) {
+	d.writeDocument(describeCommand(command, d.Output.Formatter()))
+}
+
+func (d *XmlDescriptor) DescribeInputDefinition(definition *InputDefinition, options *DescriptorOptions) {
+	var globalFlags []Flag
+	if options != nil {
+		globalFlags = options.globalFlags
+	}
+
+	d.writeDocument(describeDefinition(definition, globalFlags, d.Output.Formatter()))
+}
+
+func (d *XmlDescriptor) DescribeArgument(argument Arg, options *DescriptorOptions) {
+	d.writeDocument(describeArgument(argument, d.Output.Formatter()))
+}
+
+func (d *XmlDescriptor) DescribeFlag(flag Flag, options *DescriptorOptions) {
+	var envVars []string
+	if options != nil {
+		envVars = options.envVars
+	}
+
+	d.writeDocument(describeFlag(flag, envVars, d.Output.Formatter()))
+}
+
+func (d *XmlDescriptor) writeDocument(data any) {
+	content, _ := xml.MarshalIndent(data, "", "  ")
+	d.Output.Write(xml.Header+string(content), true, OutputRaw)
+}